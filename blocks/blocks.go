@@ -1,10 +1,18 @@
 package blocks
 
+import (
+	"time"
+
+	"github.com/harmony-one/harmony/numeric"
+)
+
 // BlockResult - statistics for each block
 type BlockResult struct {
 	ShardID     uint32
 	BlockNumber uint64
+	Timestamp   time.Time
 	TxCount     uint64
 	TPS         float64
+	Volume      numeric.Dec
 	Successful  bool
 }
@@ -0,0 +1,51 @@
+package blocks
+
+import (
+	"time"
+
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+)
+
+// DateRange - resolve the block range (toBlockNumber is exclusive) covering all blocks produced between from and to
+func DateRange(node string, from time.Time, to time.Time) (fromBlockNumber uint64, toBlockNumber uint64, err error) {
+	latestBlockNumber, err := sdkRPC.GetCurrentBlockNumber(node)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fromBlockNumber, err = FirstBlockAfter(node, from, latestBlockNumber)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	toBlockNumber, err = FirstBlockAfter(node, to, latestBlockNumber)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return fromBlockNumber, toBlockNumber, nil
+}
+
+// FirstBlockAfter - binary search for the first block produced at or after a given time
+// If no such block exists latestBlockNumber+1 is returned
+func FirstBlockAfter(node string, moment time.Time, latestBlockNumber uint64) (uint64, error) {
+	low := uint64(0)
+	high := latestBlockNumber + 1
+
+	for low < high {
+		middle := low + (high-low)/2
+
+		block, err := sdkRPC.GetBlockByNumber(middle, false, node)
+		if err != nil {
+			return 0, err
+		}
+
+		if block.Timestamp.Before(moment) {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+
+	return low, nil
+}
@@ -0,0 +1,71 @@
+package blocks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/SebastianJ/harmony-stats/config"
+)
+
+var (
+	maxReportedFailures = 20
+)
+
+// Fetch - invoke lookup for every block between fromBlockNumber and toBlockNumber (exclusive), running at most <concurrency> lookups at a time
+// Returns the block numbers for which the lookup failed, sorted in ascending order
+func Fetch(fromBlockNumber uint64, toBlockNumber uint64, lookup func(blockNumber uint64) error) (failed []uint64) {
+	concurrency := uint64(1)
+	if config.Configuration.Concurrency > 0 {
+		concurrency = uint64(config.Configuration.Concurrency)
+	}
+
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	failed = []uint64{}
+
+	for blockNumber := fromBlockNumber; blockNumber < toBlockNumber; blockNumber++ {
+		waitGroup.Add(1)
+		go func(blockNumber uint64) {
+			defer waitGroup.Done()
+
+			if err := lookup(blockNumber); err != nil {
+				mutex.Lock()
+				failed = append(failed, blockNumber)
+				mutex.Unlock()
+			}
+		}(blockNumber)
+
+		// Wait every <concurrency count> number of blocks before proceeding to queue up more goroutines
+		if (blockNumber-fromBlockNumber+1)%concurrency == 0 {
+			waitGroup.Wait()
+		}
+	}
+
+	waitGroup.Wait()
+
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i] < failed[j]
+	})
+
+	return failed
+}
+
+// ReportFailures - output the blocks that couldn't be looked up so that gaps in the results are visible
+func ReportFailures(shard uint32, failed []uint64) {
+	if len(failed) == 0 {
+		return
+	}
+
+	blockNumbers := []string{}
+	for index, blockNumber := range failed {
+		if index == maxReportedFailures {
+			blockNumbers = append(blockNumbers, "...")
+			break
+		}
+		blockNumbers = append(blockNumbers, fmt.Sprintf("%d", blockNumber))
+	}
+
+	fmt.Printf("Failed to look up %d block(s) for shard %d, these blocks are excluded from the results: %s\n", len(failed), shard, strings.Join(blockNumbers, ", "))
+}
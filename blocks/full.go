@@ -0,0 +1,37 @@
+package blocks
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/SebastianJ/harmony-stats/rpc"
+)
+
+// FullBlocks - retrieve all blocks (including full transaction bodies) between fromBlockNumber and toBlockNumber (exclusive)
+// Blocks that couldn't be retrieved are returned separately so callers can report them instead of silently skipping them
+func FullBlocks(node string, fromBlockNumber uint64, toBlockNumber uint64) (blockResults []rpc.FullBlock, failed []uint64) {
+	var mutex sync.Mutex
+	blockResults = []rpc.FullBlock{}
+
+	failed = Fetch(fromBlockNumber, toBlockNumber, func(blockNumber uint64) error {
+		fmt.Printf("Looking up full block information for block %d (node: %s)\n", blockNumber, node)
+
+		block, err := rpc.GetFullBlockByNumber(blockNumber, node)
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		blockResults = append(blockResults, block)
+		mutex.Unlock()
+
+		return nil
+	})
+
+	sort.Slice(blockResults, func(i, j int) bool {
+		return blockResults[i].BlockNumber < blockResults[j].BlockNumber
+	})
+
+	return blockResults, failed
+}
@@ -0,0 +1,74 @@
+package blocks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+)
+
+// TargetShards - resolve the shards to analyze based on a shard flag ("all" or a specific shard id)
+func TargetShards(shardFlag string) (targetShards []uint32, err error) {
+	shardFlag = strings.ToLower(shardFlag)
+
+	if shardFlag == "all" {
+		for i := uint32(0); i < uint32(config.Configuration.Network.API.ShardCount); i++ {
+			targetShards = append(targetShards, i)
+		}
+	} else {
+		shard, err := strconv.Atoi(shardFlag)
+		if err != nil {
+			return targetShards, err
+		}
+		targetShards = append(targetShards, uint32(shard))
+	}
+
+	return targetShards, nil
+}
+
+// Range - resolve the block range to analyze based on from/to/count flags (negative values are treated as unset)
+// toBlockNumber is exclusive, counts exceeding the end of the range are capped at the genesis block
+func Range(node string, from int, to int, count int) (fromBlockNumber uint64, toBlockNumber uint64, err error) {
+	latestBlockNumber, err := sdkRPC.GetCurrentBlockNumber(node)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fmt.Printf("latestBlockNumber is now: %d\n", latestBlockNumber)
+
+	if from >= 0 && to >= 0 {
+		fromBlockNumber = uint64(from)
+		toBlockNumber = uint64(to)
+	} else if from >= 0 && to < 0 {
+		fromBlockNumber = uint64(from)
+		toBlockNumber = latestBlockNumber
+	} else if from >= 0 && count >= 0 {
+		fromBlockNumber = uint64(from)
+		toBlockNumber = fromBlockNumber + uint64(count)
+	} else if to >= 0 && count >= 0 {
+		toBlockNumber = uint64(to)
+		fromBlockNumber = blocksBefore(toBlockNumber, count)
+	} else if from < 0 && to < 0 && count > 0 {
+		toBlockNumber = latestBlockNumber
+		fromBlockNumber = blocksBefore(toBlockNumber, count)
+	} else {
+		fromBlockNumber = 0
+		toBlockNumber = latestBlockNumber
+	}
+
+	if fromBlockNumber > toBlockNumber {
+		return 0, 0, fmt.Errorf("the from block #%d can't be after the to block #%d", fromBlockNumber, toBlockNumber)
+	}
+
+	return fromBlockNumber, toBlockNumber, nil
+}
+
+func blocksBefore(toBlockNumber uint64, count int) uint64 {
+	if uint64(count) > toBlockNumber {
+		return 0
+	}
+
+	return toBlockNumber - uint64(count)
+}
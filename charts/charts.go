@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/utils"
 	"github.com/golang/freetype/truetype"
 	chart "github.com/wcharczuk/go-chart"
 	"github.com/wcharczuk/go-chart/drawing"
@@ -32,6 +33,8 @@ var (
 		"light_gray":        "f9f9f9",
 		"light_gray_stroke": "eeeeee",
 		"mint_green_darker": "56dea5",
+		"alert_red":         "e94b3c",
+		"alert_red_darker":  "c93a2c",
 	}
 
 	dateFormat string = "2006-01-02"
@@ -59,8 +62,76 @@ func loadFont(family string, version string) (*truetype.Font, error) {
 	return font, nil
 }
 
-// GenerateBarChart - generates a bar chart based on supplied data
-func GenerateBarChart(fileName string, title string, bars []chart.Value) error {
+// GenerateBarChart - generates a bar chart based on supplied data using a custom y axis name and formatter, fitting all bars within the chart canvas
+// Bars that already have a style set keep it, all other bars use the default style
+func GenerateBarChart(fileName string, title string, yAxisName string, yAxisFormatter chart.ValueFormatter, bars []chart.Value) error {
+	barWidth, barSpacing := fittedBarSizes(len(bars))
+	return renderBarChart(fileName, title, yAxisName, yAxisFormatter, bars, barWidth, barSpacing)
+}
+
+// AlertBarStyle - bar style used to highlight bars that need attention
+func AlertBarStyle() chart.Style {
+	return chart.Style{
+		StrokeColor: drawing.ColorFromHex(colors["alert_red_darker"]),
+		FillColor:   drawing.ColorFromHex(colors["alert_red"]),
+		StrokeWidth: 1,
+	}
+}
+
+// GenerateHistogramChart - generates a histogram (bar chart of value counts per bucket) based on supplied values
+func GenerateHistogramChart(fileName string, title string, unit string, values []float64, bucketCount int) error {
+	if bucketCount <= 0 {
+		bucketCount = 1
+	}
+
+	min, max := utils.MinMax(values)
+	bucketSize := (max - min) / float64(bucketCount)
+	if bucketSize <= 0 {
+		bucketSize = 1
+	}
+
+	counts := make([]int, bucketCount)
+	for _, value := range values {
+		index := int((value - min) / bucketSize)
+		if index >= bucketCount {
+			index = bucketCount - 1
+		}
+		counts[index]++
+	}
+
+	bars := []chart.Value{}
+	for index, count := range counts {
+		lower := min + float64(index)*bucketSize
+		bars = append(bars, chart.Value{
+			Label: fmt.Sprintf("%.1f-%.1f%s", lower, lower+bucketSize, unit),
+			Value: float64(count),
+		})
+	}
+
+	// Fit all buckets within the chart canvas
+	slotWidth := 1700 / bucketCount
+	barWidth := slotWidth * 3 / 5
+
+	printer := message.NewPrinter(language.English)
+
+	return renderBarChart(fileName, title, "Count", func(v interface{}) string {
+		return printer.Sprintf("%d", int(math.RoundToEven(v.(float64))))
+	}, bars, barWidth, slotWidth-barWidth)
+}
+
+// fittedBarSizes - use the default bar sizes when possible and shrink the bars when they wouldn't fit within the chart canvas
+func fittedBarSizes(barCount int) (barWidth int, barSpacing int) {
+	if barCount <= 0 || 1700/barCount >= 200 {
+		return 50, 150
+	}
+
+	slotWidth := 1700 / barCount
+	barWidth = slotWidth * 3 / 5
+
+	return barWidth, slotWidth - barWidth
+}
+
+func renderBarChart(fileName string, title string, yAxisName string, yAxisFormatter chart.ValueFormatter, bars []chart.Value, barWidth int, barSpacing int) error {
 	filePath, err := setupChartPath(fileName)
 	if err != nil {
 		return err
@@ -82,7 +153,6 @@ func GenerateBarChart(fileName string, title string, bars []chart.Value) error {
 		return err
 	}
 
-	printer := message.NewPrinter(language.English)
 	padding := 50
 	graph := chart.BarChart{
 		Title: title,
@@ -109,10 +179,8 @@ func GenerateBarChart(fileName string, title string, bars []chart.Value) error {
 			StrokeWidth: 1,
 		},
 		YAxis: chart.YAxis{
-			Name: "Rewards",
-			ValueFormatter: func(v interface{}) string {
-				return printer.Sprintf("%d ONE", int(math.RoundToEven(v.(float64))))
-			},
+			Name:           yAxisName,
+			ValueFormatter: yAxisFormatter,
 			Style: chart.Style{
 				Font:      firaSansRegular,
 				FontColor: drawing.ColorFromHex(colors["fira_sans_normal"]),
@@ -122,14 +190,16 @@ func GenerateBarChart(fileName string, title string, bars []chart.Value) error {
 			Font:     nunitoBold,
 			TextWrap: 0,
 		},
-		BarWidth:   50,
-		BarSpacing: 150,
+		BarWidth:   barWidth,
+		BarSpacing: barSpacing,
 	}
 
 	styledBars := []chart.Value{}
 	for _, bar := range bars {
 		styledBar := bar
-		styledBar.Style = style
+		if styledBar.Style.IsZero() {
+			styledBar.Style = style
+		}
 		styledBars = append(styledBars, styledBar)
 	}
 	graph.Bars = styledBars
@@ -165,14 +235,6 @@ func GenerateTimeSeriesChart(fileName string, seriesTitle string, xAxisLabel str
 	extraAxisSeries := mainSeries
 	extraAxisSeries.YAxis = chart.YAxisSecondary
 
-	xTicks := make([]chart.Tick, len(xValues))
-	for index, date := range xValues {
-		xTicks[index] = chart.Tick{
-			Value: float64(date.UnixNano()),
-			Label: date.Format(dateFormat),
-		}
-	}
-
 	padding := 50
 	graph := chart.Chart{
 		Width:  1920,
@@ -202,7 +264,7 @@ func GenerateTimeSeriesChart(fileName string, seriesTitle string, xAxisLabel str
 		},
 		XAxis: chart.XAxis{
 			Name:  xAxisLabel,
-			Ticks: xTicks,
+			Ticks: timeTicks(xValues),
 		},
 		Series: []chart.Series{
 			mainSeries,
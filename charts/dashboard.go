@@ -0,0 +1,208 @@
+package charts
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"image"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/freetype"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+var (
+	dashboardColumns = 2
+
+	dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { background: #{{.Background}}; color: #{{.TextColor}}; font-family: sans-serif; margin: 40px; }
+h1 { color: #{{.TitleColor}}; }
+.panels { display: grid; grid-template-columns: repeat({{.Columns}}, 1fr); gap: 20px; }
+.panels img { width: 100%; border: 1px solid #{{.Stroke}}; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<ul>
+{{range .Details}}<li>{{.}}</li>
+{{end}}</ul>
+<div class="panels">
+{{range .Panels}}<img src="{{.}}">
+{{end}}</div>
+</body>
+</html>
+`))
+)
+
+// GenerateDashboardPNG - combine previously generated charts into a single one page image, with a header containing the title and details
+// Panels are laid out in a grid using the order they were supplied in
+func GenerateDashboardPNG(fileName string, title string, details []string, panels []string) error {
+	filePath, err := setupChartPath(fileName)
+	if err != nil {
+		return err
+	}
+
+	images := []image.Image{}
+	panelWidth, panelHeight := 0, 0
+	for _, panel := range panels {
+		panelImage, err := loadPanel(panel)
+		if err != nil {
+			return err
+		}
+
+		bounds := panelImage.Bounds()
+		if bounds.Dx() > panelWidth {
+			panelWidth = bounds.Dx()
+		}
+		if bounds.Dy() > panelHeight {
+			panelHeight = bounds.Dy()
+		}
+
+		images = append(images, panelImage)
+	}
+
+	columns := dashboardColumns
+	if len(images) < columns {
+		columns = len(images)
+	}
+	if columns == 0 {
+		columns = 1
+	}
+	rows := (len(images) + columns - 1) / columns
+
+	padding := 50
+	headerHeight := padding*2 + 60 + len(details)*36
+	width := columns * panelWidth
+	if width == 0 {
+		width = 1920
+	}
+
+	dashboard := image.NewRGBA(image.Rect(0, 0, width, headerHeight+rows*panelHeight))
+	draw.Draw(dashboard, dashboard.Bounds(), image.NewUniform(drawing.ColorFromHex(colors["light_gray"])), image.Point{}, draw.Src)
+
+	if err = drawHeader(dashboard, title, details, padding); err != nil {
+		return err
+	}
+
+	for index, panelImage := range images {
+		offset := image.Pt((index%columns)*panelWidth, headerHeight+(index/columns)*panelHeight)
+		draw.Draw(dashboard, panelImage.Bounds().Add(offset), panelImage, panelImage.Bounds().Min, draw.Src)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return png.Encode(file, dashboard)
+}
+
+// GenerateDashboardHTML - combine previously generated charts into a single html page, the charts are embedded so the page is self contained
+func GenerateDashboardHTML(fileName string, title string, details []string, panels []string) error {
+	filePath, err := setupChartPath(fileName)
+	if err != nil {
+		return err
+	}
+
+	// The panels are trusted png files generated by the charts package, so the data urls embedding them are marked as safe
+	panelURLs := []template.URL{}
+	for _, panel := range panels {
+		panelPath, err := setupChartPath(panel)
+		if err != nil {
+			return err
+		}
+
+		panelBytes, err := ioutil.ReadFile(panelPath)
+		if err != nil {
+			return err
+		}
+
+		panelURLs = append(panelURLs, template.URL("data:image/png;base64,"+base64.StdEncoding.EncodeToString(panelBytes)))
+	}
+
+	data := struct {
+		Title      string
+		Details    []string
+		Panels     []template.URL
+		Columns    int
+		Background string
+		TextColor  string
+		TitleColor string
+		Stroke     string
+	}{
+		Title:      title,
+		Details:    details,
+		Panels:     panelURLs,
+		Columns:    dashboardColumns,
+		Background: colors["light_gray"],
+		TextColor:  colors["nunito_normal"],
+		TitleColor: colors["electric_blue"],
+		Stroke:     colors["light_gray_stroke"],
+	}
+
+	var buffer bytes.Buffer
+	if err = dashboardTemplate.Execute(&buffer, data); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filePath, buffer.Bytes(), 0644)
+}
+
+func loadPanel(panel string) (image.Image, error) {
+	panelPath, err := setupChartPath(panel)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(panelPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return png.Decode(file)
+}
+
+func drawHeader(dashboard *image.RGBA, title string, details []string, padding int) error {
+	nunitoBold, err := loadFont("Nunito", "Black")
+	if err != nil {
+		return err
+	}
+
+	firaSansRegular, err := loadFont("FiraSans", "Regular")
+	if err != nil {
+		return err
+	}
+
+	context := freetype.NewContext()
+	context.SetDPI(72)
+	context.SetClip(dashboard.Bounds())
+	context.SetDst(dashboard)
+
+	context.SetFont(nunitoBold)
+	context.SetFontSize(48)
+	context.SetSrc(image.NewUniform(drawing.ColorFromHex(colors["electric_blue"])))
+	if _, err = context.DrawString(title, freetype.Pt(padding, padding+48)); err != nil {
+		return err
+	}
+
+	context.SetFont(firaSansRegular)
+	context.SetFontSize(26)
+	context.SetSrc(image.NewUniform(drawing.ColorFromHex(colors["fira_sans_normal"])))
+	for index, detail := range details {
+		if _, err = context.DrawString(detail, freetype.Pt(padding, padding+60+(index+1)*36)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package charts
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	chart "github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+var (
+	seriesColors = []string{
+		"electric_blue",
+		"mint_green_darker",
+		"midnight_blue",
+		"cool_gray",
+	}
+
+	maxTimeTicks = 12
+)
+
+// Series - a named time series to render as part of a multi series chart
+type Series struct {
+	Title     string
+	YValues   []float64
+	Secondary bool
+}
+
+// GenerateMultiTimeSeriesChart - generate a chart for multiple time series sharing the same x values
+// Series flagged as secondary are plotted against the secondary (right hand) y axis
+func GenerateMultiTimeSeriesChart(fileName string, xAxisLabel string, yAxisLabel string, secondaryYAxisLabel string, xValues []time.Time, series []Series, details []string) error {
+	filePath, err := setupChartPath(fileName)
+	if err != nil {
+		return err
+	}
+
+	chartSeries := []chart.Series{}
+	for index, s := range series {
+		timeSeries := chart.TimeSeries{
+			Name: s.Title,
+			Style: chart.Style{
+				StrokeColor: drawing.ColorFromHex(colors[seriesColors[index%len(seriesColors)]]),
+				StrokeWidth: 2,
+			},
+			XValues: xValues,
+			YValues: s.YValues,
+		}
+
+		if s.Secondary {
+			timeSeries.YAxis = chart.YAxisSecondary
+		}
+
+		chartSeries = append(chartSeries, timeSeries)
+	}
+
+	padding := 50
+	graph := chart.Chart{
+		Width:  1920,
+		Height: 1080,
+		Background: chart.Style{
+			Padding: chart.Box{
+				Top:    padding,
+				Bottom: padding,
+				Left:   padding,
+				Right:  padding,
+			},
+		},
+		Canvas: chart.Style{
+			FillColor: drawing.ColorFromHex(colors["light_gray"]),
+		},
+		YAxis: chart.YAxis{
+			Name: yAxisLabel,
+			ValueFormatter: func(v interface{}) string {
+				return fmt.Sprintf("%d", int(v.(float64)))
+			},
+		},
+		YAxisSecondary: chart.YAxis{
+			Name: secondaryYAxisLabel,
+			ValueFormatter: func(v interface{}) string {
+				return fmt.Sprintf("%.1f", v.(float64))
+			},
+		},
+		XAxis: chart.XAxis{
+			Name:  xAxisLabel,
+			Ticks: timeTicks(xValues),
+		},
+		Series: chartSeries,
+	}
+
+	detailsStyle := chart.Style{
+		FillColor:   drawing.ColorFromHex(colors["electric_blue"]),
+		FontColor:   drawing.ColorFromHex(colors["mint_green"]),
+		FontSize:    11.0,
+		StrokeColor: drawing.ColorFromHex(colors["electric_blue"]),
+		StrokeWidth: chart.DefaultAxisLineWidth,
+	}
+
+	graph.Elements = []chart.Renderable{chart.LegendThin(&graph)}
+	if len(details) > 0 {
+		graph.Elements = append(graph.Elements, DetailsBox(&graph, details, detailsStyle))
+	}
+
+	file, err := os.Create(filePath)
+	defer file.Close()
+	if err != nil {
+		return err
+	}
+
+	graph.Render(chart.PNG, file)
+
+	return nil
+}
+
+// timeTicks - generate at most maxTimeTicks evenly spread x axis ticks, using a date or a time label depending on the covered period
+func timeTicks(xValues []time.Time) []chart.Tick {
+	ticks := []chart.Tick{}
+	if len(xValues) == 0 {
+		return ticks
+	}
+
+	format := dateFormat
+	if xValues[len(xValues)-1].Sub(xValues[0]) < 48*time.Hour {
+		format = "15:04:05"
+	}
+
+	step := 1
+	if len(xValues) > maxTimeTicks {
+		step = len(xValues) / maxTimeTicks
+	}
+
+	for index := 0; index < len(xValues); index += step {
+		ticks = append(ticks, chart.Tick{
+			Value: float64(xValues[index].UnixNano()),
+			Label: xValues[index].Format(format),
+		})
+	}
+
+	return ticks
+}
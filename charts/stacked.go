@@ -0,0 +1,93 @@
+package charts
+
+import (
+	"os"
+
+	chart "github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// GenerateStackedBarChart - generate a stacked bar chart where every bar is split into segments relative to the bar's total
+// Segments without a style are colored per segment index using the series colors
+func GenerateStackedBarChart(fileName string, title string, bars []chart.StackedBar) error {
+	filePath, err := setupChartPath(fileName)
+	if err != nil {
+		return err
+	}
+
+	nunitoBold, err := loadFont("Nunito", "Black")
+	if err != nil {
+		return err
+	}
+
+	firaSansRegular, err := loadFont("FiraSans", "Regular")
+	if err != nil {
+		return err
+	}
+
+	barWidth, barSpacing := fittedBarSizes(len(bars))
+
+	styledBars := []chart.StackedBar{}
+	for _, bar := range bars {
+		styledBar := chart.StackedBar{Name: bar.Name, Width: barWidth}
+		for index, value := range bar.Values {
+			if value.Style.IsZero() {
+				value.Style = chart.Style{
+					StrokeColor: drawing.ColorFromHex(colors["light_gray_stroke"]),
+					FillColor:   drawing.ColorFromHex(colors[seriesColors[index%len(seriesColors)]]),
+					FontColor:   drawing.ColorFromHex(colors["light_gray"]),
+					Font:        firaSansRegular,
+					StrokeWidth: 1,
+				}
+			}
+			styledBar.Values = append(styledBar.Values, value)
+		}
+		styledBars = append(styledBars, styledBar)
+	}
+
+	padding := 50
+	graph := chart.StackedBarChart{
+		Title: title,
+		TitleStyle: chart.Style{
+			Padding: chart.Box{
+				Top: 5,
+			},
+			Font:      nunitoBold,
+			FontColor: drawing.ColorFromHex(colors["electric_blue"]),
+		},
+		Width:  1920,
+		Height: 1080,
+		Background: chart.Style{
+			Padding: chart.Box{
+				Top:    padding,
+				Bottom: padding,
+				Left:   padding,
+				Right:  padding,
+			},
+		},
+		Canvas: chart.Style{
+			FillColor:   drawing.ColorFromHex(colors["light_gray"]),
+			StrokeColor: drawing.ColorFromHex(colors["light_gray_stroke"]),
+			StrokeWidth: 1,
+		},
+		YAxis: chart.Style{
+			Font:      firaSansRegular,
+			FontColor: drawing.ColorFromHex(colors["fira_sans_normal"]),
+		},
+		XAxis: chart.Style{
+			Font: nunitoBold,
+		},
+		BarSpacing: barSpacing,
+		Bars:       styledBars,
+	}
+
+	file, err := os.Create(filePath)
+	defer file.Close()
+	if err != nil {
+		return err
+	}
+
+	graph.Render(chart.PNG, file)
+
+	return nil
+}
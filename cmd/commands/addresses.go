@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/stats/addresses"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmdAddresses := &cobra.Command{
+		Use:   "addresses",
+		Short: "Active address statistics",
+		Long:  "Generate statistics for the number of unique active addresses (senders and receivers) per day",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyzeActiveAddresses(cmd)
+		},
+	}
+
+	config.AddressArgs = config.AddressFlags{}
+	cmdAddresses.Flags().StringVar(&config.AddressArgs.Shard, "shard", "all", "--shard <shardID>")
+	cmdAddresses.Flags().StringVar(&config.AddressArgs.FromDate, "from", "", "--from <YYYY-MM-DD>")
+	cmdAddresses.Flags().StringVar(&config.AddressArgs.ToDate, "to", "", "--to <YYYY-MM-DD>")
+	cmdAddresses.Flags().IntVar(&config.AddressArgs.Days, "days", 7, "--days <days>")
+
+	RootCmd.AddCommand(cmdAddresses)
+}
+
+func analyzeActiveAddresses(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := addresses.AnalyzeActiveAddresses(); err != nil {
+		return err
+	}
+
+	return nil
+}
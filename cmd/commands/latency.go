@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/stats/latency"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmdLatency := &cobra.Command{
+		Use:   "latency",
+		Short: "Transaction latency statistics",
+		Long:  "Generate inclusion and finality latency statistics for submitted transactions based on their send times",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyzeLatency(cmd)
+		},
+	}
+
+	config.LatencyArgs = config.LatencyFlags{}
+	cmdLatency.Flags().StringVar(&config.LatencyArgs.Shard, "shard", "all", "--shard <shardID>")
+	cmdLatency.Flags().IntVar(&config.LatencyArgs.From, "from", -1, "--from <blockNumber>")
+	cmdLatency.Flags().IntVar(&config.LatencyArgs.To, "to", -1, "--to <blockNumber>")
+	cmdLatency.Flags().IntVar(&config.LatencyArgs.Count, "count", -1, "--count <count>")
+	cmdLatency.Flags().StringVar(&config.LatencyArgs.Submissions, "submissions", "", "--submissions <path to csv of tx hashes and send times>")
+	cmdLatency.Flags().IntVar(&config.LatencyArgs.Sample, "sample", 0, "--sample <max number of transactions to analyze>")
+	cmdLatency.Flags().IntVar(&config.LatencyArgs.Buckets, "buckets", 20, "--buckets <histogram buckets>")
+
+	RootCmd.AddCommand(cmdLatency)
+}
+
+func analyzeLatency(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := latency.AnalyzeLatency(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/stats/nodes"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmdNodes := &cobra.Command{
+		Use:   "nodes",
+		Short: "Node health and sync status",
+		Long:  "Check block height, lag, peer count, version and response latency for every node in --nodes or every shard endpoint",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkNodes(cmd)
+		},
+	}
+
+	RootCmd.AddCommand(cmdNodes)
+}
+
+func checkNodes(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := nodes.CheckNodes(); err != nil {
+		return err
+	}
+
+	return nil
+}
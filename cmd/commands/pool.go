@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/stats/pool"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmdPool := &cobra.Command{
+		Use:   "pool",
+		Short: "Transaction pool statistics",
+		Long:  "Periodically sample the pending (regular and staking) transaction pool sizes and graph them against TPS",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return monitorPool(cmd)
+		},
+	}
+
+	config.PoolArgs = config.PoolFlags{}
+	cmdPool.Flags().StringVar(&config.PoolArgs.Shard, "shard", "all", "--shard <shardID>")
+	cmdPool.Flags().IntVar(&config.PoolArgs.Duration, "duration", 300, "--duration <seconds>")
+	cmdPool.Flags().IntVar(&config.PoolArgs.Interval, "interval", 5, "--interval <seconds>")
+
+	RootCmd.AddCommand(cmdPool)
+}
+
+func monitorPool(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := pool.MonitorPool(); err != nil {
+		return err
+	}
+
+	return nil
+}
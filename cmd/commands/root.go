@@ -42,6 +42,7 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&config.Args.Path, "path", ".", "<path>")
 	RootCmd.PersistentFlags().StringVar(&config.Args.Export, "export", "", "<path>")
 	RootCmd.PersistentFlags().StringVar(&config.Args.ExportPath, "export-path", "./exports", "<path>")
+	RootCmd.PersistentFlags().StringVar(&config.Args.AddressFormat, "address-format", "one", "--address-format <one|hex|both>")
 
 	RootCmd.AddCommand(&cobra.Command{
 		Use:   "version",
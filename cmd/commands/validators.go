@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/SebastianJ/harmony-stats/config"
 	"github.com/SebastianJ/harmony-stats/validators"
 	"github.com/spf13/cobra"
@@ -9,6 +12,19 @@ import (
 func init() {
 	config.ValidatorArgs = config.ValidatorFlags{}
 	config.ValidatorArgs.Filter = config.FilterFlags{}
+	config.ValidatorArgs.Retry = config.RetryFlags{}
+	config.ValidatorArgs.Daily = config.DailyFlags{}
+	config.ValidatorArgs.Uptime = config.UptimeFlags{}
+	config.ValidatorArgs.Delegators = config.DelegatorFlags{}
+	config.ValidatorArgs.Commissions = config.CommissionFlags{}
+	config.ValidatorArgs.Diff = config.DiffFlags{}
+	config.ValidatorArgs.Leaderboard = config.LeaderboardFlags{}
+	config.ValidatorArgs.APR = config.APRFlags{}
+	config.ValidatorArgs.Elections = config.ElectionFlags{}
+	config.ValidatorArgs.Status = config.StatusFlags{}
+	config.ValidatorArgs.Show = config.ShowFlags{}
+	config.ValidatorArgs.Watch = config.WatchFlags{}
+	config.ValidatorArgs.Overview = config.OverviewFlags{}
 
 	cmdValidators := &cobra.Command{
 		Use:   "validators",
@@ -20,13 +36,29 @@ func init() {
 		},
 	}
 
+	cmdValidators.PersistentFlags().StringVar(&config.ValidatorArgs.Filter.Expression, "filter", "", "--filter <expression>, e.g. \"total_delegation > 10000000 and elected and bls_keys >= 2 and name ~ /foo/\"")
 	cmdValidators.PersistentFlags().StringVar(&config.ValidatorArgs.Filter.Field, "filter.field", "", "--filter.field <field>")
 	cmdValidators.PersistentFlags().StringVar(&config.ValidatorArgs.Filter.Value, "filter.value", "", "--filter.value <value>")
 	cmdValidators.PersistentFlags().StringVar(&config.ValidatorArgs.Filter.Mode, "filter.mode", "contains", "--filter.mode <mode>")
+	cmdValidators.PersistentFlags().IntVar(&config.ValidatorArgs.Retry.Attempts, "retry.attempts", 3, "--retry.attempts <count>, the number of attempts per validator page request")
+	cmdValidators.PersistentFlags().IntVar(&config.ValidatorArgs.Retry.Wait, "retry.wait", 2, "--retry.wait <seconds>, the number of seconds to wait between attempts")
 	cmdValidators.PersistentFlags().BoolVar(&config.ValidatorArgs.Elected, "elected", false, "--elected")
+	cmdValidators.PersistentFlags().Float64Var(&config.ValidatorArgs.Uptime.Threshold, "uptime.threshold", 0.0, "--uptime.threshold <percentage>, validators signing at or below this percentage are flagged (defaults to the protocol's 2/3 availability threshold)")
 
 	cmdValidators.AddCommand(analyzeCmd())
 	cmdValidators.AddCommand(graphsCmd())
+	cmdValidators.AddCommand(decentralizationCmd())
+	cmdValidators.AddCommand(delegatorsCmd())
+	cmdValidators.AddCommand(commissionsCmd())
+	cmdValidators.AddCommand(diffCmd())
+	cmdValidators.AddCommand(aprCmd())
+	cmdValidators.AddCommand(keysCmd())
+	cmdValidators.AddCommand(slashingCmd())
+	cmdValidators.AddCommand(statusCmd())
+	cmdValidators.AddCommand(undelegationsCmd())
+	cmdValidators.AddCommand(showCmd())
+	cmdValidators.AddCommand(watchCmd())
+	cmdValidators.AddCommand(overviewCmd())
 
 	RootCmd.AddCommand(cmdValidators)
 }
@@ -78,6 +110,9 @@ func graphsCmd() *cobra.Command {
 		},
 	}
 
+	cmdDaily.Flags().BoolVar(&config.ValidatorArgs.Daily.Cumulative, "cumulative", false, "--cumulative, graph the total number of validators over time instead of the number of created validators per day")
+	cmdDaily.Flags().BoolVar(&config.ValidatorArgs.Daily.OverlayElected, "overlay-elected", false, "--overlay-elected, overlay the number of created validators that are currently elected")
+
 	cmdLeaderboard := &cobra.Command{
 		Use:   "leaderboard",
 		Short: "Generate validator leaderboard",
@@ -87,8 +122,38 @@ func graphsCmd() *cobra.Command {
 		},
 	}
 
+	cmdLeaderboard.Flags().StringVar(&config.ValidatorArgs.Leaderboard.Metric, "metric", "rewards", fmt.Sprintf("--metric <metric>, the metric to rank validators by: %s", strings.Join(validators.LeaderboardMetricNames(), ", ")))
+	cmdLeaderboard.Flags().IntVar(&config.ValidatorArgs.Leaderboard.Limit, "limit", 20, "--limit <count>, the number of validators to include in the leaderboard (0 for all)")
+	cmdLeaderboard.Flags().StringVar(&config.ValidatorArgs.Leaderboard.Order, "order", "desc", "--order <asc|desc>")
+	cmdLeaderboard.Flags().IntVar(&config.ValidatorArgs.Leaderboard.BLSKeys, "bls-keys", 1, "--bls-keys <count>, only include validators with exactly this number of BLS keys (0 for any)")
+
+	cmdUptime := &cobra.Command{
+		Use:   "uptime",
+		Short: "Generate validator uptime graph",
+		Long:  "Generate a graph of the current epoch uptime of validators, starting with the worst performing validators",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return graphUptime(cmd)
+		},
+	}
+
+	cmdUptime.Flags().IntVar(&config.ValidatorArgs.Uptime.Limit, "limit", 50, "--limit <count>, the number of validators to include in the graph (0 for all)")
+
+	cmdElections := &cobra.Command{
+		Use:   "elections",
+		Short: "Generate election history graph",
+		Long:  "Generate a graph of the number of elected validators, elected BLS keys and winning bids per epoch",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return graphElections(cmd)
+		},
+	}
+
+	cmdElections.Flags().Uint64Var(&config.ValidatorArgs.Elections.FromEpoch, "from-epoch", 0, "--from-epoch <epoch>, the first epoch to include")
+	cmdElections.Flags().Uint64Var(&config.ValidatorArgs.Elections.ToEpoch, "to-epoch", 0, "--to-epoch <epoch>, the last epoch to include")
+
 	cmdGraphs.AddCommand(cmdDaily)
 	cmdGraphs.AddCommand(cmdLeaderboard)
+	cmdGraphs.AddCommand(cmdUptime)
+	cmdGraphs.AddCommand(cmdElections)
 
 	return cmdGraphs
 }
@@ -116,3 +181,360 @@ func graphLeaderboard(cmd *cobra.Command) error {
 
 	return nil
 }
+
+func graphUptime(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.UptimeGraph(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func graphElections(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Elections(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func decentralizationCmd() *cobra.Command {
+	cmdDecentralization := &cobra.Command{
+		Use:   "decentralization",
+		Short: "Calculate decentralization metrics",
+		Long:  "Calculate stake distribution and decentralization metrics (Nakamoto coefficient, Gini, HHI) for the elected validators",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyzeDecentralization(cmd)
+		},
+	}
+
+	return cmdDecentralization
+}
+
+func analyzeDecentralization(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Decentralization(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func delegatorsCmd() *cobra.Command {
+	cmdDelegators := &cobra.Command{
+		Use:   "delegators",
+		Short: "Analyze delegators",
+		Long:  "Analyze and export all delegations made to validators",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyzeDelegators(cmd)
+		},
+	}
+
+	cmdDelegators.Flags().IntVar(&config.ValidatorArgs.Delegators.Limit, "limit", 20, "--limit <count>, the number of top delegators to output (0 for all)")
+
+	return cmdDelegators
+}
+
+func analyzeDelegators(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Delegators(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func commissionsCmd() *cobra.Command {
+	cmdCommissions := &cobra.Command{
+		Use:   "commissions",
+		Short: "Analyze validator commissions",
+		Long:  "Graph the commission rate distribution of the elected validators and optionally report commission changes since a previous snapshot",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyzeCommissions(cmd)
+		},
+	}
+
+	cmdCommissions.Flags().StringVar(&config.ValidatorArgs.Commissions.Since, "since", "", "--since <snapshot>, a snapshot id (or latest/previous) or a previous csv/json export from validators analyze to diff commission rates against")
+	cmdCommissions.Flags().Float64Var(&config.ValidatorArgs.Commissions.BucketSize, "bucket", 5.0, "--bucket <percentage>, the commission rate range per bar")
+
+	return cmdCommissions
+}
+
+func analyzeCommissions(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Commissions(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func diffCmd() *cobra.Command {
+	cmdDiff := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare validator snapshots",
+		Long:  "Compare two validator snapshots (stored by validators analyze) and report new/removed validators, election changes, delegation and reward deltas and BLS key changes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diffValidators(cmd)
+		},
+	}
+
+	cmdDiff.Flags().StringVar(&config.ValidatorArgs.Diff.From, "from", "previous", "--from <snapshot>, a snapshot id, path or one of latest/previous")
+	cmdDiff.Flags().StringVar(&config.ValidatorArgs.Diff.To, "to", "latest", "--to <snapshot>, a snapshot id, path or one of latest/previous")
+
+	return cmdDiff
+}
+
+func diffValidators(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Diff(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func aprCmd() *cobra.Command {
+	cmdAPR := &cobra.Command{
+		Use:   "apr",
+		Short: "Estimate validator APR",
+		Long:  "Estimate per validator APR (net of commission) from the rewards accumulated between two snapshots or epochs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return estimateAPR(cmd)
+		},
+	}
+
+	cmdAPR.Flags().StringVar(&config.ValidatorArgs.APR.From, "from", "previous", "--from <snapshot>, a snapshot id, path or one of latest/previous")
+	cmdAPR.Flags().StringVar(&config.ValidatorArgs.APR.To, "to", "latest", "--to <snapshot>, a snapshot id, path or one of latest/previous")
+	cmdAPR.Flags().Uint64Var(&config.ValidatorArgs.APR.FromEpoch, "from-epoch", 0, "--from-epoch <epoch>, use the validator state at the end of this epoch instead of a snapshot")
+	cmdAPR.Flags().Uint64Var(&config.ValidatorArgs.APR.ToEpoch, "to-epoch", 0, "--to-epoch <epoch>, use the validator state at the end of this epoch instead of a snapshot")
+	cmdAPR.Flags().IntVar(&config.ValidatorArgs.APR.Limit, "limit", 20, "--limit <count>, the number of validators to include in the graph (0 for all)")
+
+	return cmdAPR
+}
+
+func estimateAPR(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.EstimateAPR(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func keysCmd() *cobra.Command {
+	cmdKeys := &cobra.Command{
+		Use:   "keys",
+		Short: "Analyze BLS keys and slots per shard",
+		Long:  "Analyze how validator BLS keys and elected slots are distributed across shards",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyzeKeys(cmd)
+		},
+	}
+
+	return cmdKeys
+}
+
+func analyzeKeys(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Keys(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func slashingCmd() *cobra.Command {
+	cmdSlashing := &cobra.Command{
+		Use:   "slashing",
+		Short: "Report slashed validators",
+		Long:  "Report validators that have been slashed and banned for double signing",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reportSlashing(cmd)
+		},
+	}
+
+	return cmdSlashing
+}
+
+func reportSlashing(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Slashing(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func statusCmd() *cobra.Command {
+	cmdStatus := &cobra.Command{
+		Use:   "status",
+		Short: "Track validator status transitions",
+		Long:  "Track validator status transitions between two snapshots, highlighting validators that dropped out of the committee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return trackStatus(cmd)
+		},
+	}
+
+	cmdStatus.Flags().StringVar(&config.ValidatorArgs.Status.From, "from", "previous", "--from <snapshot>, a snapshot id, path or one of latest/previous")
+	cmdStatus.Flags().StringVar(&config.ValidatorArgs.Status.To, "to", "latest", "--to <snapshot>, a snapshot id, path or one of latest/previous")
+
+	return cmdStatus
+}
+
+func trackStatus(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.StatusTransitions(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func undelegationsCmd() *cobra.Command {
+	cmdUndelegations := &cobra.Command{
+		Use:   "undelegations",
+		Short: "Analyze pending undelegations",
+		Long:  "Report the tokens currently undelegating per validator and network wide, including the expected unlock schedule",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyzeUndelegations(cmd)
+		},
+	}
+
+	return cmdUndelegations
+}
+
+func analyzeUndelegations(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Undelegations(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func showCmd() *cobra.Command {
+	cmdShow := &cobra.Command{
+		Use:   "show <address|name>",
+		Short: "Show a single validator",
+		Long:  "Show everything about a single validator: info, delegations, balance, uptime, rewards history, BLS keys and committee membership",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showValidator(cmd, args)
+		},
+	}
+
+	cmdShow.Flags().StringVar(&config.ValidatorArgs.Show.Dashboard, "dashboard", "", "--dashboard <png|html>, render a one page dashboard of the validator's charts")
+	cmdShow.Flags().IntVar(&config.ValidatorArgs.Show.Limit, "limit", 10, "--limit <count>, the number of largest delegations to output")
+
+	return cmdShow
+}
+
+func showValidator(cmd *cobra.Command, args []string) error {
+	config.ValidatorArgs.Show.Validator = args[0]
+
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Show(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func watchCmd() *cobra.Command {
+	cmdWatch := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch validators and raise alerts",
+		Long:  "Periodically poll validators and raise alerts when they leave the committee, their uptime or wallet balance drops below a threshold or their commission changes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watchValidators(cmd)
+		},
+	}
+
+	cmdWatch.Flags().StringSliceVar(&config.ValidatorArgs.Watch.Addresses, "addresses", []string{}, "--addresses address1,address2")
+	cmdWatch.Flags().IntVar(&config.ValidatorArgs.Watch.Interval, "interval", 60, "--interval <seconds>")
+	cmdWatch.Flags().IntVar(&config.ValidatorArgs.Watch.Duration, "duration", 0, "--duration <seconds>, 0 watches until interrupted")
+	cmdWatch.Flags().StringSliceVar(&config.ValidatorArgs.Watch.Outputs, "output", []string{"stdout"}, "--output stdout,jsonl,webhook")
+	cmdWatch.Flags().StringVar(&config.ValidatorArgs.Watch.WebhookURL, "webhook.url", "", "--webhook.url <url>, e.g. http://localhost:8080/alerts")
+	cmdWatch.Flags().Float64Var(&config.ValidatorArgs.Watch.BalanceThreshold, "balance.threshold", 0, "--balance.threshold <amount>, alert when the wallet balance drops below this amount of ONE")
+	cmdWatch.Flags().Uint64Var(&config.ValidatorArgs.Watch.MinimumToSign, "uptime.min-to-sign", 30, "--uptime.min-to-sign <blocks>, the number of blocks a validator has to sign during the current epoch before uptime alerts are raised")
+
+	return cmdWatch
+}
+
+func watchValidators(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Watch(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func overviewCmd() *cobra.Command {
+	cmdOverview := &cobra.Command{
+		Use:   "overview",
+		Short: "Network wide staking overview",
+		Long:  "Summarize network wide staking statistics as json and a multi panel dashboard",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generateOverview(cmd)
+		},
+	}
+
+	cmdOverview.Flags().IntVar(&config.ValidatorArgs.Overview.Limit, "limit", 10, "--limit <count>, the number of validators to include in the top stakes chart")
+
+	return cmdOverview
+}
+
+func generateOverview(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := validators.Overview(); err != nil {
+		return err
+	}
+
+	return nil
+}
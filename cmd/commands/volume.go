@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/stats/volume"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmdVolume := &cobra.Command{
+		Use:   "volume",
+		Short: "Transaction volume statistics",
+		Long:  "Generate statistics for the amount of ONE transferred per block / per day",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyzeVolume(cmd)
+		},
+	}
+
+	config.VolumeArgs = config.VolumeFlags{}
+	cmdVolume.Flags().StringVar(&config.VolumeArgs.Shard, "shard", "all", "--shard <shardID>")
+	cmdVolume.Flags().IntVar(&config.VolumeArgs.From, "from", -1, "--from <blockNumber>")
+	cmdVolume.Flags().IntVar(&config.VolumeArgs.To, "to", -1, "--to <blockNumber>")
+	cmdVolume.Flags().IntVar(&config.VolumeArgs.Count, "count", -1, "--count <count>")
+
+	RootCmd.AddCommand(cmdVolume)
+}
+
+func analyzeVolume(cmd *cobra.Command) error {
+	if err := config.Configure(); err != nil {
+		return err
+	}
+
+	if err := volume.AnalyzeVolume(); err != nil {
+		return err
+	}
+
+	return nil
+}
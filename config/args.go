@@ -2,17 +2,18 @@ package config
 
 // PersistentFlags represents the persistent flags
 type PersistentFlags struct {
-	Network      string
-	Mode         string
-	Node         string
-	Nodes        []string
-	Timeout      int
-	Concurrency  int
-	Verbose      bool
-	VerboseGoSDK bool
-	Path         string
-	Export       string
-	ExportPath   string
+	Network       string
+	Mode          string
+	Node          string
+	Nodes         []string
+	Timeout       int
+	Concurrency   int
+	Verbose       bool
+	VerboseGoSDK  bool
+	Path          string
+	Export        string
+	ExportPath    string
+	AddressFormat string
 }
 
 // TPSFlags tps related configuration flags
@@ -24,16 +25,151 @@ type TPSFlags struct {
 	BlockTime int
 }
 
+// VolumeFlags transaction volume related configuration flags
+type VolumeFlags struct {
+	Shard string
+	From  int
+	To    int
+	Count int
+}
+
+// AddressFlags active address related configuration flags
+type AddressFlags struct {
+	Shard    string
+	FromDate string
+	ToDate   string
+	Days     int
+}
+
+// LatencyFlags transaction latency related configuration flags
+type LatencyFlags struct {
+	Shard       string
+	From        int
+	To          int
+	Count       int
+	Submissions string
+	Sample      int
+	Buckets     int
+}
+
+// PoolFlags transaction pool related configuration flags
+type PoolFlags struct {
+	Shard    string
+	Duration int
+	Interval int
+}
+
 // ValidatorFlags validator related configuration flags
 type ValidatorFlags struct {
-	Filter   FilterFlags
-	Elected  bool
-	Balances bool
+	Filter      FilterFlags
+	Retry       RetryFlags
+	Daily       DailyFlags
+	Uptime      UptimeFlags
+	Delegators  DelegatorFlags
+	Commissions CommissionFlags
+	Diff        DiffFlags
+	Leaderboard LeaderboardFlags
+	APR         APRFlags
+	Elections   ElectionFlags
+	Status      StatusFlags
+	Show        ShowFlags
+	Watch       WatchFlags
+	Overview    OverviewFlags
+	Elected     bool
+	Balances    bool
+}
+
+// RetryFlags - retry settings for validator RPC requests
+type RetryFlags struct {
+	Attempts int
+	Wait     int
 }
 
 // FilterFlags - filter validators based on certain criteria
 type FilterFlags struct {
-	Field string
-	Value string
-	Mode  string
+	Expression string
+	Field      string
+	Value      string
+	Mode       string
+}
+
+// DailyFlags - daily validator graph related flags
+type DailyFlags struct {
+	Cumulative     bool
+	OverlayElected bool
+}
+
+// UptimeFlags - validator uptime related flags
+type UptimeFlags struct {
+	Threshold float64
+	Limit     int
+}
+
+// DelegatorFlags - delegator analysis related flags
+type DelegatorFlags struct {
+	Limit int
+}
+
+// CommissionFlags - commission analysis related flags
+type CommissionFlags struct {
+	Since      string
+	BucketSize float64
+}
+
+// DiffFlags - snapshot diff related flags
+type DiffFlags struct {
+	From string
+	To   string
+}
+
+// LeaderboardFlags - validator leaderboard related flags
+type LeaderboardFlags struct {
+	Metric  string
+	Limit   int
+	Order   string
+	BLSKeys int
+}
+
+// APRFlags - APR estimation related flags
+type APRFlags struct {
+	From      string
+	To        string
+	FromEpoch uint64
+	ToEpoch   uint64
+	Limit     int
+}
+
+// ElectionFlags - election history related flags
+type ElectionFlags struct {
+	FromEpoch uint64
+	ToEpoch   uint64
+}
+
+// StatusFlags - status transition related flags
+type StatusFlags struct {
+	From string
+	To   string
+}
+
+// ShowFlags - single validator profile related flags
+type ShowFlags struct {
+	Validator string
+	Dashboard string
+	Limit     int
+}
+
+// WatchFlags - validator monitoring related flags
+type WatchFlags struct {
+	Addresses        []string
+	Interval         int
+	Duration         int
+	Outputs          []string
+	WebhookURL       string
+	BalanceThreshold float64
+	MinimumToSign    uint64
+}
+
+// OverviewFlags - staking overview related flags
+type OverviewFlags struct {
+	Limit int
 }
@@ -30,8 +30,9 @@ type Network struct {
 
 // Export - export settings
 type Export struct {
-	Path   string
-	Format string
+	Path          string
+	Format        string
+	AddressFormat string
 }
 
 // Styling - represents settings for styling the log output
@@ -23,6 +23,18 @@ var Args PersistentFlags
 // TPSArgs is a collection of TPS related flags parsed using Cobra
 var TPSArgs TPSFlags
 
+// VolumeArgs is a collection of transaction volume related flags parsed using Cobra
+var VolumeArgs VolumeFlags
+
+// AddressArgs is a collection of active address related flags parsed using Cobra
+var AddressArgs AddressFlags
+
+// LatencyArgs is a collection of transaction latency related flags parsed using Cobra
+var LatencyArgs LatencyFlags
+
+// PoolArgs is a collection of transaction pool related flags parsed using Cobra
+var PoolArgs PoolFlags
+
 // ValidatorArgs is a collection of validator related flags parsed using Cobra
 var ValidatorArgs ValidatorFlags
 
@@ -127,6 +139,15 @@ func configureExports() error {
 		Configuration.Export.Format = Args.Export
 	}
 
+	Configuration.Export.AddressFormat = strings.ToLower(Args.AddressFormat)
+	switch Configuration.Export.AddressFormat {
+	case "":
+		Configuration.Export.AddressFormat = "one"
+	case "one", "hex", "both":
+	default:
+		return fmt.Errorf("invalid address format %s - valid options: one, hex or both", Args.AddressFormat)
+	}
+
 	return nil
 }
 
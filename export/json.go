@@ -0,0 +1,30 @@
+package export
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/SebastianJ/harmony-stats/config"
+)
+
+// ExportJSON - exports data as indented json
+func ExportJSON(fileName string, data interface{}) (string, error) {
+	filePath := filepath.Join(config.Configuration.Export.Path, fileName)
+	dirPath, _ := filepath.Split(filePath)
+	if err := os.MkdirAll(dirPath, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	bytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(filePath, bytes, 0644); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
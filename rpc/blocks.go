@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/utils"
+	goSdkCommon "github.com/harmony-one/go-sdk/pkg/common"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// FullBlockWrapper - wrapper for the GetBlockByNumber RPC method when including full transaction bodies
+type FullBlockWrapper struct {
+	ID      string          `json:"id" yaml:"id"`
+	JSONRPC string          `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  FullBlock       `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// FullBlock - block info including full transaction bodies
+type FullBlock struct {
+	BlockNumber  uint64        `json:"-" yaml:"-"`
+	Hash         string        `json:"hash,omitempty" yaml:"hash,omitempty"`
+	RawTimestamp string        `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Timestamp    time.Time     `json:"-" yaml:"-"`
+	Transactions []Transaction `json:"transactions,omitempty" yaml:"transactions,omitempty"`
+}
+
+// Transaction - a regular (non-staking) transaction as returned by the RPC
+type Transaction struct {
+	Hash      string      `json:"hash,omitempty" yaml:"hash,omitempty"`
+	From      string      `json:"from,omitempty" yaml:"from,omitempty"`
+	To        string      `json:"to,omitempty" yaml:"to,omitempty"`
+	RawValue  string      `json:"value,omitempty" yaml:"value,omitempty"`
+	Value     numeric.Dec `json:"-" yaml:"-"`
+	ShardID   uint32      `json:"shardID" yaml:"shardID"`
+	ToShardID uint32      `json:"toShardID" yaml:"toShardID"`
+}
+
+// GetFullBlockByNumber - retrieve a specific block including all of its transactions
+func GetFullBlockByNumber(blockNumber uint64, node string) (FullBlock, error) {
+	response := FullBlockWrapper{}
+	result := FullBlock{}
+	blockNum := fmt.Sprintf("0x%x", blockNumber)
+
+	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetBlockByNumber, node, []interface{}{blockNum, true})
+	if err != nil {
+		return result, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return result, err
+	}
+
+	if response.Error.Message != "" {
+		return result, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	result = response.Result
+	result.BlockNumber = blockNumber
+	if err = result.Initialize(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Initialize - initialize and convert values for a given FullBlock struct
+func (block *FullBlock) Initialize() error {
+	if block.RawTimestamp != "" {
+		unixTime, err := utils.HexToDecimal(block.RawTimestamp)
+		if err != nil {
+			return err
+		}
+
+		block.Timestamp = time.Unix(int64(unixTime), 0).UTC()
+	}
+
+	for i := range block.Transactions {
+		block.Transactions[i].Initialize()
+	}
+
+	return nil
+}
+
+// Initialize - initialize and convert values for a given Transaction struct
+func (tx *Transaction) Initialize() {
+	tx.Value = numeric.ZeroDec()
+	if tx.RawValue != "" {
+		tx.Value = goSdkCommon.NewDecFromHex(tx.RawValue).Quo(numeric.NewDec(denominations.One))
+	}
+}
+
+// LatestHeaderWrapper - wrapper for the GetLatestBlockHeader RPC method
+type LatestHeaderWrapper struct {
+	ID      string          `json:"id" yaml:"id"`
+	JSONRPC string          `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  Header          `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Header - the latest block header of a given shard
+type Header struct {
+	BlockNumber uint64    `json:"blockNumber" yaml:"blockNumber"`
+	ShardID     uint32    `json:"shardID" yaml:"shardID"`
+	Epoch       uint64    `json:"epoch" yaml:"epoch"`
+	UnixTime    int64     `json:"unixtime" yaml:"unixtime"`
+	Timestamp   time.Time `json:"-" yaml:"-"`
+}
+
+// GetLatestHeader - retrieve the latest block header from a given node
+func GetLatestHeader(node string) (Header, error) {
+	response := LatestHeaderWrapper{}
+
+	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetLatestBlockHeader, node, []interface{}{})
+	if err != nil {
+		return Header{}, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return Header{}, err
+	}
+
+	if response.Error.Message != "" {
+		return Header{}, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	header := response.Result
+	header.Timestamp = time.Unix(header.UnixTime, 0).UTC()
+
+	return header, nil
+}
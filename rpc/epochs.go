@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+var (
+	// EpochLastBlockMethod - the RPC method for looking up the last block of an epoch (not defined in go-sdk)
+	EpochLastBlockMethod = "hmy_epochLastBlock"
+
+	// EpochCommitteeMethod - the RPC method for looking up the committee of a given epoch (not defined in go-sdk)
+	EpochCommitteeMethod = "hmy_getValidators"
+)
+
+// EpochLastBlockWrapper - wrapper for the EpochLastBlock RPC method
+type EpochLastBlockWrapper struct {
+	ID      string          `json:"id" yaml:"id"`
+	JSONRPC string          `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  uint64          `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// EpochLastBlock - look up the last block number of a given epoch, only available on the beacon chain
+func EpochLastBlock(epoch uint64, node string) (uint64, error) {
+	response := EpochLastBlockWrapper{}
+
+	bytes, err := goSdkRPC.RawRequest(EpochLastBlockMethod, node, []interface{}{epoch})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return 0, err
+	}
+
+	if response.Error.Message != "" {
+		return 0, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	return response.Result, nil
+}
+
+// EpochCommitteeWrapper - wrapper for the EpochCommittee RPC method
+type EpochCommitteeWrapper struct {
+	ID      string          `json:"id" yaml:"id"`
+	JSONRPC string          `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  EpochCommittee  `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// EpochCommittee - the committee of a given shard during a given epoch, containing one member per slot (BLS key)
+type EpochCommittee struct {
+	ShardID uint32            `json:"shardID" yaml:"shardID"`
+	Members []CommitteeMember `json:"validators" yaml:"validators"`
+}
+
+// CommitteeMember - the owner of a committee slot
+type CommitteeMember struct {
+	Address string `json:"address" yaml:"address"`
+}
+
+// GetEpochCommittee - look up the committee of the shard the given node belongs to for a given epoch
+func GetEpochCommittee(epoch uint64, node string) (EpochCommittee, error) {
+	response := EpochCommitteeWrapper{}
+
+	bytes, err := goSdkRPC.RawRequest(EpochCommitteeMethod, node, []interface{}{epoch})
+	if err != nil {
+		return EpochCommittee{}, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return EpochCommittee{}, err
+	}
+
+	if response.Error.Message != "" {
+		return EpochCommittee{}, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	return response.Result, nil
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	"github.com/harmony-one/go-lib/utils"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+// NodeMetadataWrapper - wrapper for the GetNodeMetadata RPC method
+type NodeMetadataWrapper struct {
+	ID      string          `json:"id" yaml:"id"`
+	JSONRPC string          `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  NodeMetadata    `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NodeMetadata - node metadata as reported by a node
+type NodeMetadata struct {
+	BLSPublicKeys []string `json:"blskey,omitempty" yaml:"blskey,omitempty"`
+	Version       string   `json:"version,omitempty" yaml:"version,omitempty"`
+	NetworkType   string   `json:"network,omitempty" yaml:"network,omitempty"`
+	IsLeader      bool     `json:"is-leader,omitempty" yaml:"is-leader,omitempty"`
+	ShardID       uint32   `json:"shard-id" yaml:"shard-id"`
+	CurrentEpoch  uint64   `json:"current-epoch,omitempty" yaml:"current-epoch,omitempty"`
+	Role          string   `json:"role,omitempty" yaml:"role,omitempty"`
+	Archival      bool     `json:"is-archival,omitempty" yaml:"is-archival,omitempty"`
+	NodeBootTime  int64    `json:"node-unix-start-time,omitempty" yaml:"node-unix-start-time,omitempty"`
+}
+
+// GetNodeMetadata - get the metadata for a given node
+func GetNodeMetadata(node string) (NodeMetadata, error) {
+	response := NodeMetadataWrapper{}
+	result := NodeMetadata{}
+
+	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetNodeMetadata, node, []interface{}{})
+	if err != nil {
+		return result, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return result, err
+	}
+
+	if response.Error.Message != "" {
+		return result, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	return response.Result, nil
+}
+
+// GetPeerCount - get the number of peers a given node is connected to
+func GetPeerCount(node string) (uint64, error) {
+	response := sdkRPC.RPCGenericSingleHexResponse{}
+
+	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.PeerCount, node, []interface{}{})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return 0, err
+	}
+
+	if response.Result == "" {
+		return 0, fmt.Errorf("empty peer count result")
+	}
+
+	return utils.HexToDecimal(response.Result)
+}
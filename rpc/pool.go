@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+)
+
+var (
+	// PendingStakingTransactionsMethod - the RPC method for the staking transactions pool (not defined in go-sdk)
+	PendingStakingTransactionsMethod = "hmy_pendingStakingTransactions"
+)
+
+// PendingTransactionsWrapper - wrapper for the PendingTransactions / PendingStakingTransactions RPC methods
+type PendingTransactionsWrapper struct {
+	ID      string            `json:"id" yaml:"id"`
+	JSONRPC string            `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  []json.RawMessage `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// PendingTransactionCount - get the number of regular transactions currently waiting in the transaction pool of a given node
+func PendingTransactionCount(node string) (int, error) {
+	return pendingCount(goSdkRPC.Method.GetPendingTxnsInPool, node)
+}
+
+// PendingStakingTransactionCount - get the number of staking transactions currently waiting in the transaction pool of a given node
+func PendingStakingTransactionCount(node string) (int, error) {
+	return pendingCount(PendingStakingTransactionsMethod, node)
+}
+
+func pendingCount(rpcMethod string, node string) (int, error) {
+	response := PendingTransactionsWrapper{}
+
+	bytes, err := goSdkRPC.RawRequest(rpcMethod, node, []interface{}{})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return 0, err
+	}
+
+	if response.Error.Message != "" {
+		return 0, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	return len(response.Result), nil
+}
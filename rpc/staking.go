@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// MedianStakeSnapshotWrapper - wrapper for the GetMedianRawStakeSnapshot RPC method
+type MedianStakeSnapshotWrapper struct {
+	ID      string              `json:"id" yaml:"id"`
+	JSONRPC string              `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  MedianStakeSnapshot `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// MedianStakeSnapshot - the outcome of the latest EPoS slot auction
+type MedianStakeSnapshot struct {
+	MedianStake          numeric.Dec  `json:"epos-median-stake" yaml:"epos-median-stake"`
+	MaximumExternalSlots int          `json:"max-external-slots" yaml:"max-external-slots"`
+	Winners              []SlotWinner `json:"epos-slot-winners" yaml:"epos-slot-winners"`
+}
+
+// SlotWinner - a BLS key that won a slot in the EPoS auction
+type SlotWinner struct {
+	Address      string      `json:"slot-owner" yaml:"slot-owner"`
+	BLSPublicKey string      `json:"bls-public-key" yaml:"bls-public-key"`
+	RawStake     numeric.Dec `json:"raw-stake" yaml:"raw-stake"`
+	EPoSStake    numeric.Dec `json:"eposed-stake" yaml:"eposed-stake"`
+}
+
+// GetMedianStakeSnapshot - retrieve the latest EPoS median stake snapshot, only available on the beacon chain
+func GetMedianStakeSnapshot(node string) (MedianStakeSnapshot, error) {
+	response := MedianStakeSnapshotWrapper{}
+	result := MedianStakeSnapshot{}
+
+	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetMedianRawStakeSnapshot, node, []interface{}{})
+	if err != nil {
+		return result, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return result, err
+	}
+
+	if response.Error.Message != "" {
+		return result, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	result = response.Result
+	result.Initialize()
+
+	return result, nil
+}
+
+// Initialize - convert all stake amounts from atto to ONE
+func (snapshot *MedianStakeSnapshot) Initialize() {
+	snapshot.MedianStake = attoToOne(snapshot.MedianStake)
+
+	for i := range snapshot.Winners {
+		snapshot.Winners[i].RawStake = attoToOne(snapshot.Winners[i].RawStake)
+		snapshot.Winners[i].EPoSStake = attoToOne(snapshot.Winners[i].EPoSStake)
+	}
+}
+
+func attoToOne(amount numeric.Dec) numeric.Dec {
+	if amount.IsNil() {
+		return numeric.ZeroDec()
+	}
+
+	return amount.Quo(numeric.NewDec(denominations.One))
+}
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+	goSdkRPC "github.com/harmony-one/go-sdk/pkg/rpc"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// ValidatorPageSize - the number of validators returned per page by the GetAllValidatorInformation RPC method
+const ValidatorPageSize = 100
+
+// ValidatorInformationWrapper - wrapper for the GetAllValidatorInformation RPC method
+type ValidatorInformationWrapper struct {
+	ID      string                 `json:"id" yaml:"id"`
+	JSONRPC string                 `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  []ValidatorInformation `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ValidatorInformation - the validator information decoded by go-lib extended with the fields go-lib doesn't decode
+// Metrics are only populated for validators currently in the committee, the booted status only for validators that aren't
+type ValidatorInformation struct {
+	sdkValidator.RPCValidatorResult
+	Metrics      *ValidatorMetrics `json:"metrics" yaml:"metrics"`
+	BootedStatus *string           `json:"booted-status" yaml:"booted-status"`
+}
+
+// ValidatorMetrics - the committee metrics of a validator
+type ValidatorMetrics struct {
+	ByBLSKey []struct {
+		Key ElectedKey `json:"key" yaml:"key"`
+	} `json:"by-bls-key" yaml:"by-bls-key"`
+}
+
+// ValidatorDetailWrapper - wrapper for the GetValidatorInformation RPC method
+type ValidatorDetailWrapper struct {
+	ID      string               `json:"id" yaml:"id"`
+	JSONRPC string               `json:"jsonrpc" yaml:"jsonrpc"`
+	Result  ValidatorInformation `json:"result" yaml:"result"`
+	Error   sdkRPC.RPCError      `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ElectedKey - a BLS key that is part of the current committee, the effective stake is denominated in atto
+type ElectedKey struct {
+	BLSPublicKey   string      `json:"bls-public-key" yaml:"bls-public-key"`
+	ShardID        uint32      `json:"shard-id" yaml:"shard-id"`
+	EffectiveStake numeric.Dec `json:"effective-stake" yaml:"effective-stake"`
+}
+
+// GetValidatorInformationPage - retrieve a single page of validator information
+func GetValidatorInformationPage(node string, page int) ([]ValidatorInformation, error) {
+	response := ValidatorInformationWrapper{}
+
+	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetAllValidatorInformation, node, []interface{}{page})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Error.Message != "" {
+		return nil, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	for index := range response.Result {
+		if err = response.Result[index].Initialize(); err != nil {
+			return nil, err
+		}
+	}
+
+	return response.Result, nil
+}
+
+// GetValidatorDetails - look up the information of a single validator, including the fields go-lib doesn't decode
+func GetValidatorDetails(node string, address string) (ValidatorInformation, error) {
+	response := ValidatorDetailWrapper{}
+
+	bytes, err := goSdkRPC.RawRequest(goSdkRPC.Method.GetValidatorInformation, node, []interface{}{address})
+	if err != nil {
+		return response.Result, err
+	}
+
+	if err = json.Unmarshal(bytes, &response); err != nil {
+		return response.Result, err
+	}
+
+	if response.Error.Message != "" {
+		return response.Result, fmt.Errorf("%s (%d)", response.Error.Message, response.Error.Code)
+	}
+
+	if err = response.Result.Initialize(); err != nil {
+		return response.Result, err
+	}
+
+	return response.Result, nil
+}
+
+// ElectedKeys - the BLS keys of the validator that are currently in the committee
+func (information ValidatorInformation) ElectedKeys() []ElectedKey {
+	electedKeys := []ElectedKey{}
+
+	if information.Metrics != nil {
+		for _, metric := range information.Metrics.ByBLSKey {
+			electedKeys = append(electedKeys, metric.Key)
+		}
+	}
+
+	return electedKeys
+}
+
+// EffectiveStake - the effective (EPoS) stake in ONE summed over the validator's elected keys in the current committee
+// found is false when the committee metrics don't contain an effective stake, e.g. for validators that aren't elected
+func (information ValidatorInformation) EffectiveStake() (effectiveStake numeric.Dec, found bool) {
+	effectiveStake = numeric.ZeroDec()
+
+	for _, electedKey := range information.ElectedKeys() {
+		if electedKey.EffectiveStake.IsNil() {
+			continue
+		}
+
+		effectiveStake = effectiveStake.Add(electedKey.EffectiveStake)
+		found = true
+	}
+
+	return attoToOne(effectiveStake), found
+}
+
+// Booted - the booted status of the validator, empty for validators currently in the committee
+func (information ValidatorInformation) Booted() string {
+	if information.BootedStatus == nil {
+		return ""
+	}
+
+	return *information.BootedStatus
+}
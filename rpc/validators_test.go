@@ -0,0 +1,33 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/harmony-one/harmony/numeric"
+)
+
+func TestEffectiveStake(t *testing.T) {
+	response := `{
+		"metrics": {
+			"by-bls-key": [
+				{"key": {"bls-public-key": "a", "shard-id": 0, "effective-stake": "1000000000000000000000.000000000000000000"}},
+				{"key": {"bls-public-key": "b", "shard-id": 1, "effective-stake": "500000000000000000000.000000000000000000"}}
+			]
+		}
+	}`
+
+	information := ValidatorInformation{}
+	if err := json.Unmarshal([]byte(response), &information); err != nil {
+		t.Fatalf("failed to decode the validator information - error: %s", err.Error())
+	}
+
+	effectiveStake, found := information.EffectiveStake()
+	if !found || !effectiveStake.Equal(numeric.NewDec(1500)) {
+		t.Errorf("EffectiveStake() = %s, %t, expected 1500, true", effectiveStake, found)
+	}
+
+	if effectiveStake, found = (ValidatorInformation{}).EffectiveStake(); found || !effectiveStake.IsZero() {
+		t.Errorf("EffectiveStake() without metrics = %s, %t, expected 0, false", effectiveStake, found)
+	}
+}
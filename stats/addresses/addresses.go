@@ -0,0 +1,215 @@
+package addresses
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/blocks"
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	"github.com/elliotchance/orderedmap"
+)
+
+var (
+	timeFormat string = "2006-01-02"
+)
+
+// DailyAddresses - the unique addresses that were active on a given date
+type DailyAddresses struct {
+	Senders   map[string]bool
+	Receivers map[string]bool
+	Active    map[string]bool
+}
+
+// AnalyzeActiveAddresses - analyze the number of unique active addresses per day across the targeted shards
+func AnalyzeActiveAddresses() error {
+	fromDate, toDate, err := parseDates()
+	if err != nil {
+		return err
+	}
+
+	targetShards, err := blocks.TargetShards(config.AddressArgs.Shard)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Will analyze unique active addresses from %s to %s - network: %s, mode: %s\n", fromDate.Format(timeFormat), toDate.Format(timeFormat), config.Configuration.Network.Name, config.Configuration.Network.Mode)
+
+	dates := orderedmap.NewOrderedMap()
+	for date := fromDate; !date.After(toDate); date = date.AddDate(0, 0, 1) {
+		dates.Set(date.Format(timeFormat), newDailyAddresses())
+	}
+
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	for _, shard := range targetShards {
+		waitGroup.Add(1)
+		go func(shard uint32) {
+			defer waitGroup.Done()
+
+			shardBlocks, err := retrieveShardBlocks(shard, fromDate, toDate.AddDate(0, 0, 1))
+			if err != nil {
+				fmt.Printf("Failed to retrieve blocks for shard %d - error: %s\n", shard, err.Error())
+				return
+			}
+
+			mutex.Lock()
+			identifyAddressesPerDate(shardBlocks, dates)
+			mutex.Unlock()
+		}(shard)
+	}
+
+	waitGroup.Wait()
+
+	xAxisData := []time.Time{}
+	yAxisData := []float64{}
+	for el := dates.Front(); el != nil; el = el.Next() {
+		dateString := el.Key.(string)
+		dailyAddresses := el.Value.(*DailyAddresses)
+
+		date, err := time.Parse(timeFormat, dateString)
+		if err != nil {
+			return err
+		}
+
+		xAxisData = append(xAxisData, date)
+		yAxisData = append(yAxisData, float64(len(dailyAddresses.Active)))
+
+		fmt.Printf("Date %s - unique senders: %d, unique receivers: %d, unique active addresses: %d\n", dateString, len(dailyAddresses.Senders), len(dailyAddresses.Receivers), len(dailyAddresses.Active))
+	}
+
+	fileName := fmt.Sprintf("addresses/%s-%s-to-%s-daily.png", strings.ToLower(config.Configuration.Network.Name), fromDate.Format(timeFormat), toDate.Format(timeFormat))
+	err = charts.GenerateTimeSeriesChart(
+		fileName,
+		"Active Addresses",
+		"Date",
+		"Addresses",
+		xAxisData,
+		yAxisData,
+		[]string{
+			"Harmony Active Addresses Report",
+			fmt.Sprintf("Network: %s", config.Configuration.Network.Name),
+			fmt.Sprintf("Shards: %s", config.AddressArgs.Shard),
+			fmt.Sprintf("Dates: %s - %s", fromDate.Format(timeFormat), toDate.Format(timeFormat)),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportToCSV(dates)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported active address data to %s\n", csvPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+func newDailyAddresses() *DailyAddresses {
+	return &DailyAddresses{
+		Senders:   make(map[string]bool),
+		Receivers: make(map[string]bool),
+		Active:    make(map[string]bool),
+	}
+}
+
+func retrieveShardBlocks(shard uint32, from time.Time, to time.Time) ([]rpc.FullBlock, error) {
+	node := config.Configuration.Network.API.Shards[shard].Node
+
+	fromBlockNumber, toBlockNumber, err := blocks.DateRange(node, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Retrieving blocks from block #%d to block #%d for shard %d ...\n", fromBlockNumber, toBlockNumber, shard)
+
+	shardBlocks, failed := blocks.FullBlocks(node, fromBlockNumber, toBlockNumber)
+	blocks.ReportFailures(shard, failed)
+
+	return shardBlocks, nil
+}
+
+func identifyAddressesPerDate(shardBlocks []rpc.FullBlock, dates *orderedmap.OrderedMap) {
+	for _, block := range shardBlocks {
+		value, exists := dates.Get(block.Timestamp.Format(timeFormat))
+		if !exists {
+			continue
+		}
+
+		dailyAddresses := value.(*DailyAddresses)
+		for _, tx := range block.Transactions {
+			if tx.From != "" {
+				dailyAddresses.Senders[tx.From] = true
+				dailyAddresses.Active[tx.From] = true
+			}
+
+			if tx.To != "" {
+				dailyAddresses.Receivers[tx.To] = true
+				dailyAddresses.Active[tx.To] = true
+			}
+		}
+	}
+}
+
+func parseDates() (fromDate time.Time, toDate time.Time, err error) {
+	toDate = time.Now().UTC().Truncate(24 * time.Hour)
+	if config.AddressArgs.ToDate != "" {
+		if toDate, err = time.Parse(timeFormat, config.AddressArgs.ToDate); err != nil {
+			return fromDate, toDate, err
+		}
+	}
+
+	fromDate = toDate.AddDate(0, 0, -(config.AddressArgs.Days - 1))
+	if config.AddressArgs.FromDate != "" {
+		if fromDate, err = time.Parse(timeFormat, config.AddressArgs.FromDate); err != nil {
+			return fromDate, toDate, err
+		}
+	}
+
+	if fromDate.After(toDate) {
+		return fromDate, toDate, fmt.Errorf("the from date %s can't be after the to date %s", fromDate.Format(timeFormat), toDate.Format(timeFormat))
+	}
+
+	return fromDate, toDate, nil
+}
+
+func exportToCSV(dates *orderedmap.OrderedMap) (string, error) {
+	fileName := fmt.Sprintf("addresses/active-addresses-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	rows := [][]string{
+		{
+			"Date",
+			"Unique Senders",
+			"Unique Receivers",
+			"Unique Active Addresses",
+		},
+	}
+
+	for el := dates.Front(); el != nil; el = el.Next() {
+		dailyAddresses := el.Value.(*DailyAddresses)
+		rows = append(rows, []string{
+			el.Key.(string),
+			fmt.Sprintf("%d", len(dailyAddresses.Senders)),
+			fmt.Sprintf("%d", len(dailyAddresses.Receivers)),
+			fmt.Sprintf("%d", len(dailyAddresses.Active)),
+		})
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
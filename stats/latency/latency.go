@@ -0,0 +1,318 @@
+package latency
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/blocks"
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+)
+
+var (
+	percentiles = []float64{50, 90, 95, 99}
+)
+
+// TransactionResult - latency measurements for a given transaction
+// Transactions are only finalized when the block following their block (containing the commit signatures) could be retrieved
+type TransactionResult struct {
+	Hash             string
+	ShardID          uint32
+	BlockNumber      uint64
+	SentAt           time.Time
+	IncludedAt       time.Time
+	Finalized        bool
+	FinalizedAt      time.Time
+	InclusionLatency float64
+	FinalityLatency  float64
+}
+
+// AnalyzeLatency - analyze the inclusion and finality latency of submitted transactions
+func AnalyzeLatency() error {
+	if config.LatencyArgs.Submissions == "" {
+		return fmt.Errorf("you need to specify a csv file containing tx hashes and send times using --submissions")
+	}
+
+	submissions, err := readSubmissions(config.LatencyArgs.Submissions)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Loaded %d submitted transaction(s) from %s\n", len(submissions), config.LatencyArgs.Submissions)
+
+	targetShards, err := blocks.TargetShards(config.LatencyArgs.Shard)
+	if err != nil {
+		return err
+	}
+
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	results := []TransactionResult{}
+
+	for _, shard := range targetShards {
+		waitGroup.Add(1)
+		go func(shard uint32) {
+			defer waitGroup.Done()
+
+			shardResults, err := analyzeLatencyForShard(shard, submissions)
+			if err != nil {
+				fmt.Printf("Failed to analyze latency for shard %d - error: %s\n", shard, err.Error())
+				return
+			}
+
+			mutex.Lock()
+			results = append(results, shardResults...)
+			mutex.Unlock()
+		}(shard)
+	}
+
+	waitGroup.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].SentAt.Before(results[j].SentAt)
+	})
+
+	results = sample(results, config.LatencyArgs.Sample)
+	if len(results) == 0 {
+		return fmt.Errorf("none of the submitted transactions could be found in the analyzed block range")
+	}
+
+	inclusionLatencies := []float64{}
+	finalityLatencies := []float64{}
+	for _, result := range results {
+		inclusionLatencies = append(inclusionLatencies, result.InclusionLatency)
+		if result.Finalized {
+			finalityLatencies = append(finalityLatencies, result.FinalityLatency)
+		}
+	}
+
+	fmt.Printf("Found %d of %d submitted transaction(s) in the analyzed block range\n", len(results), len(submissions))
+	if unfinalized := len(results) - len(finalityLatencies); unfinalized > 0 {
+		fmt.Printf("%d transaction(s) are excluded from the finality latency since the block following their block couldn't be retrieved\n", unfinalized)
+	}
+
+	for _, line := range summarize("Inclusion latency", inclusionLatencies) {
+		fmt.Println(line)
+	}
+
+	network := strings.ToLower(config.Configuration.Network.Name)
+	if err = charts.GenerateHistogramChart(fmt.Sprintf("latency/%s-inclusion.png", network), "Transaction Inclusion Latency", "s", inclusionLatencies, config.LatencyArgs.Buckets); err != nil {
+		return err
+	}
+
+	if len(finalityLatencies) > 0 {
+		for _, line := range summarize("Finality latency", finalityLatencies) {
+			fmt.Println(line)
+		}
+
+		if err = charts.GenerateHistogramChart(fmt.Sprintf("latency/%s-finality.png", network), "Transaction Finality Latency", "s", finalityLatencies, config.LatencyArgs.Buckets); err != nil {
+			return err
+		}
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportToCSV(results)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported latency data to %s\n", csvPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+func analyzeLatencyForShard(shard uint32, submissions map[string]time.Time) ([]TransactionResult, error) {
+	node := config.Configuration.Network.API.Shards[shard].Node
+
+	fromBlockNumber, toBlockNumber, err := blocks.Range(node, config.LatencyArgs.From, config.LatencyArgs.To, config.LatencyArgs.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Starting to analyze blocks from block #%d to block #%d for shard %d ...\n", fromBlockNumber, toBlockNumber, shard)
+
+	// Commit signatures for a block are included in the next block - fetch the block following the analyzed range as well to measure finality for the last block
+	// Only transactions included in the analyzed range (toBlockNumber is exclusive, same as for every other block range) are measured
+	shardBlocks, failed := blocks.FullBlocks(node, fromBlockNumber, toBlockNumber+1)
+	blocks.ReportFailures(shard, failed)
+	blockTimestamps := make(map[uint64]time.Time)
+	for _, block := range shardBlocks {
+		blockTimestamps[block.BlockNumber] = block.Timestamp
+	}
+
+	results := []TransactionResult{}
+	for _, block := range shardBlocks {
+		if block.BlockNumber >= toBlockNumber {
+			continue
+		}
+
+		for _, tx := range block.Transactions {
+			sentAt, exists := submissions[strings.ToLower(tx.Hash)]
+			if !exists {
+				continue
+			}
+
+			results = append(results, newTransactionResult(shard, block, tx, sentAt, blockTimestamps))
+		}
+	}
+
+	return results, nil
+}
+
+func newTransactionResult(shard uint32, block rpc.FullBlock, tx rpc.Transaction, sentAt time.Time, blockTimestamps map[uint64]time.Time) TransactionResult {
+	result := TransactionResult{
+		Hash:        tx.Hash,
+		ShardID:     shard,
+		BlockNumber: block.BlockNumber,
+		SentAt:      sentAt,
+		IncludedAt:  block.Timestamp,
+	}
+
+	result.InclusionLatency = result.IncludedAt.Sub(sentAt).Seconds()
+
+	// Without the next block the finality time is unknown - using the inclusion time instead would bias the finality latency low
+	if nextTimestamp, exists := blockTimestamps[block.BlockNumber+1]; exists {
+		result.Finalized = true
+		result.FinalizedAt = nextTimestamp
+		result.FinalityLatency = result.FinalizedAt.Sub(sentAt).Seconds()
+	}
+
+	return result
+}
+
+// sample - deterministically pick an evenly spread sample of at most sampleSize results
+func sample(results []TransactionResult, sampleSize int) []TransactionResult {
+	if sampleSize <= 0 || len(results) <= sampleSize {
+		return results
+	}
+
+	sampled := []TransactionResult{}
+	step := float64(len(results)) / float64(sampleSize)
+	for i := 0; i < sampleSize; i++ {
+		sampled = append(sampled, results[int(float64(i)*step)])
+	}
+
+	return sampled
+}
+
+func summarize(title string, values []float64) []string {
+	min, max := utils.MinMax(values)
+
+	summary := []string{
+		fmt.Sprintf("%s - min: %.2fs, max: %.2fs, mean: %.2fs", title, min, max, utils.Mean(values)),
+	}
+
+	for _, percentile := range percentiles {
+		summary = append(summary, fmt.Sprintf("%s - p%d: %.2fs", title, int(percentile), utils.Percentile(values, percentile)))
+	}
+
+	return summary
+}
+
+// readSubmissions - read a csv file of tx hashes and send times (unix timestamps or RFC3339)
+func readSubmissions(path string) (map[string]time.Time, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	submissions := make(map[string]time.Time)
+	for index, record := range records {
+		if len(record) < 2 {
+			continue
+		}
+
+		hash := strings.ToLower(strings.TrimSpace(record[0]))
+		if !strings.HasPrefix(hash, "0x") {
+			// Most likely a header row
+			continue
+		}
+
+		sentAt, err := parseTimestamp(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse send time on line %d of %s - error: %s", index+1, path, err.Error())
+		}
+
+		submissions[hash] = sentAt
+	}
+
+	return submissions, nil
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	if unix, err := strconv.ParseFloat(value, 64); err == nil {
+		// Timestamps exceeding the year ~2286 in seconds are treated as millisecond timestamps
+		if unix > 1e10 {
+			unix = unix / 1000
+		}
+		seconds := int64(unix)
+		return time.Unix(seconds, int64((unix-float64(seconds))*1e9)).UTC(), nil
+	}
+
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func exportToCSV(results []TransactionResult) (string, error) {
+	fileName := fmt.Sprintf("latency/latency-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	rows := [][]string{
+		{
+			"Tx Hash",
+			"Shard",
+			"Block Number",
+			"Sent At",
+			"Included At",
+			"Finalized At",
+			"Inclusion Latency (s)",
+			"Finality Latency (s)",
+		},
+	}
+
+	for _, result := range results {
+		// Unfinalized transactions get empty finality cells
+		finalizedAt, finalityLatency := "", ""
+		if result.Finalized {
+			finalizedAt = result.FinalizedAt.Format(time.RFC3339)
+			finalityLatency = fmt.Sprintf("%.3f", result.FinalityLatency)
+		}
+
+		rows = append(rows, []string{
+			result.Hash,
+			fmt.Sprintf("%d", result.ShardID),
+			fmt.Sprintf("%d", result.BlockNumber),
+			result.SentAt.Format(time.RFC3339Nano),
+			result.IncludedAt.Format(time.RFC3339),
+			finalizedAt,
+			fmt.Sprintf("%.3f", result.InclusionLatency),
+			finalityLatency,
+		})
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
@@ -0,0 +1,221 @@
+package nodes
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+)
+
+// NodeResult - health and sync status for a given node
+// The shard is unknown (nil) for nodes that aren't configured shard endpoints and failed to report their metadata
+type NodeResult struct {
+	Node        string  `json:"node"`
+	ShardID     *uint32 `json:"shard-id,omitempty"`
+	BlockNumber uint64  `json:"block-number"`
+	Lag         uint64  `json:"lag"`
+	PeerCount   uint64  `json:"peer-count"`
+	Version     string  `json:"version"`
+	Latency     float64 `json:"latency-ms"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// CheckNodes - check the health and sync status of all configured nodes
+func CheckNodes() error {
+	nodes := targetNodes()
+
+	fmt.Printf("Checking the health and sync status of %d node(s) - network: %s, mode: %s\n", len(nodes), config.Configuration.Network.Name, config.Configuration.Network.Mode)
+
+	results := make([]NodeResult, len(nodes))
+	var waitGroup sync.WaitGroup
+
+	for index, node := range nodes {
+		waitGroup.Add(1)
+		go func(index int, node string) {
+			defer waitGroup.Done()
+			results[index] = checkNode(node)
+		}(index, node)
+	}
+
+	waitGroup.Wait()
+
+	calculateLag(results)
+
+	// Nodes with an unknown shard are listed last
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].ShardID == nil || results[j].ShardID == nil {
+			return results[j].ShardID == nil && results[i].ShardID != nil
+		}
+		return *results[i].ShardID < *results[j].ShardID
+	})
+
+	outputTable(results)
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportToCSV(results)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported node data to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("nodes/nodes-%s-UTC.json", utils.FormattedTimeString(time.Now().UTC())), results)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported node data to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// targetNodes - use the nodes explicitly supplied using --nodes or fall back to every shard endpoint
+func targetNodes() []string {
+	if len(config.Args.Nodes) > 0 {
+		return config.Args.Nodes
+	}
+
+	shardIDs := []uint32{}
+	for shardID := range config.Configuration.Network.API.Shards {
+		shardIDs = append(shardIDs, shardID)
+	}
+
+	sort.Slice(shardIDs, func(i, j int) bool {
+		return shardIDs[i] < shardIDs[j]
+	})
+
+	nodes := []string{}
+	for _, shardID := range shardIDs {
+		nodes = append(nodes, config.Configuration.Network.API.Shards[shardID].Node)
+	}
+
+	return nodes
+}
+
+func checkNode(node string) NodeResult {
+	result := NodeResult{Node: node, ShardID: configuredShard(node)}
+
+	start := time.Now()
+	blockNumber, err := sdkRPC.GetCurrentBlockNumber(node)
+	result.Latency = float64(time.Since(start).Microseconds()) / 1000.0
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.BlockNumber = blockNumber
+
+	metadata, err := rpc.GetNodeMetadata(node)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	shardID := metadata.ShardID
+	result.ShardID = &shardID
+	result.Version = metadata.Version
+
+	peerCount, err := rpc.GetPeerCount(node)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.PeerCount = peerCount
+
+	return result
+}
+
+// configuredShard - the shard a node is configured as the endpoint for, nil for any other node
+func configuredShard(node string) *uint32 {
+	for shardID, shard := range config.Configuration.Network.API.Shards {
+		if shard.Node == node {
+			configuredShardID := shardID
+			return &configuredShardID
+		}
+	}
+
+	return nil
+}
+
+// calculateLag - calculate how far behind each node is compared to the highest known head of its shard
+func calculateLag(results []NodeResult) {
+	heads := make(map[uint32]uint64)
+	for _, result := range results {
+		if result.Error == "" && result.ShardID != nil && result.BlockNumber > heads[*result.ShardID] {
+			heads[*result.ShardID] = result.BlockNumber
+		}
+	}
+
+	for index, result := range results {
+		if result.Error == "" && result.ShardID != nil {
+			results[index].Lag = heads[*result.ShardID] - result.BlockNumber
+		}
+	}
+}
+
+func outputTable(results []NodeResult) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NODE\tSHARD\tBLOCK\tLAG\tPEERS\tVERSION\tLATENCY\tERROR")
+
+	for _, result := range results {
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%d\t%d\t%s\t%.1fms\t%s\n", result.Node, formatShard(result.ShardID), result.BlockNumber, result.Lag, result.PeerCount, result.Version, result.Latency, result.Error)
+	}
+
+	writer.Flush()
+}
+
+// formatShard - unknown shards are left empty
+func formatShard(shardID *uint32) string {
+	if shardID == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d", *shardID)
+}
+
+func exportToCSV(results []NodeResult) (string, error) {
+	fileName := fmt.Sprintf("nodes/nodes-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	rows := [][]string{
+		{
+			"Node",
+			"Shard",
+			"Block Number",
+			"Lag",
+			"Peer Count",
+			"Version",
+			"Latency (ms)",
+			"Error",
+		},
+	}
+
+	for _, result := range results {
+		rows = append(rows, []string{
+			result.Node,
+			formatShard(result.ShardID),
+			fmt.Sprintf("%d", result.BlockNumber),
+			fmt.Sprintf("%d", result.Lag),
+			fmt.Sprintf("%d", result.PeerCount),
+			result.Version,
+			fmt.Sprintf("%.1f", result.Latency),
+			result.Error,
+		})
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
@@ -0,0 +1,223 @@
+package pool
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/blocks"
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+)
+
+// Sample - a single transaction pool sample for a given shard
+type Sample struct {
+	ShardID        uint32
+	Time           time.Time
+	BlockNumber    uint64
+	Pending        int
+	PendingStaking int
+	TPS            float64
+	Successful     bool
+}
+
+// MonitorPool - periodically sample the pending transaction pool sizes of the targeted shards
+func MonitorPool() error {
+	targetShards, err := blocks.TargetShards(config.PoolArgs.Shard)
+	if err != nil {
+		return err
+	}
+
+	if config.PoolArgs.Interval <= 0 {
+		return fmt.Errorf("the sampling interval has to be at least 1 second")
+	}
+
+	fmt.Printf("Will monitor the transaction pool for %d second(s) using an interval of %d second(s) - network: %s, mode: %s\n", config.PoolArgs.Duration, config.PoolArgs.Interval, config.Configuration.Network.Name, config.Configuration.Network.Mode)
+
+	var waitGroup sync.WaitGroup
+
+	for _, shard := range targetShards {
+		waitGroup.Add(1)
+		go monitorShard(shard, &waitGroup)
+	}
+
+	waitGroup.Wait()
+
+	return nil
+}
+
+func monitorShard(shard uint32, waitGroup *sync.WaitGroup) {
+	defer waitGroup.Done()
+
+	samples := collectSamples(shard)
+	if len(samples) == 0 {
+		fmt.Printf("No successful transaction pool samples were collected for shard %d\n", shard)
+		return
+	}
+
+	if err := chartSamples(shard, samples); err != nil {
+		fmt.Printf("Failed to generate the transaction pool chart for shard %d - error: %s\n", shard, err.Error())
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportToCSV(shard, samples)
+		if err != nil {
+			fmt.Printf("Failed to export transaction pool data for shard %d - error: %s\n", shard, err.Error())
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported transaction pool data for shard %d to %s\n", shard, csvPath)
+		}
+	default:
+	}
+}
+
+func collectSamples(shard uint32) []Sample {
+	node := config.Configuration.Network.API.Shards[shard].Node
+	interval := time.Duration(config.PoolArgs.Interval) * time.Second
+	deadline := time.Now().Add(time.Duration(config.PoolArgs.Duration) * time.Second)
+
+	samples := []Sample{}
+	var previous *Sample
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sample := takeSample(node, shard, previous)
+		if sample.Successful {
+			fmt.Printf("Shard %d - block: %d, pending transactions: %d, pending staking transactions: %d, TPS: %f\n", shard, sample.BlockNumber, sample.Pending, sample.PendingStaking, sample.TPS)
+			samples = append(samples, sample)
+			previous = &sample
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			break
+		}
+
+		<-ticker.C
+	}
+
+	return samples
+}
+
+func takeSample(node string, shard uint32, previous *Sample) Sample {
+	sample := Sample{ShardID: shard, Time: time.Now().UTC()}
+
+	pending, err := rpc.PendingTransactionCount(node)
+	if err != nil {
+		fmt.Printf("Failed to look up pending transactions for shard %d - error: %s\n", shard, err.Error())
+		return sample
+	}
+
+	pendingStaking, err := rpc.PendingStakingTransactionCount(node)
+	if err != nil {
+		fmt.Printf("Failed to look up pending staking transactions for shard %d - error: %s\n", shard, err.Error())
+		return sample
+	}
+
+	blockNumber, err := sdkRPC.GetCurrentBlockNumber(node)
+	if err != nil {
+		fmt.Printf("Failed to look up the current block number for shard %d - error: %s\n", shard, err.Error())
+		return sample
+	}
+
+	sample.Pending = pending
+	sample.PendingStaking = pendingStaking
+	sample.BlockNumber = blockNumber
+	sample.Successful = true
+
+	if previous != nil {
+		sample.TPS = tpsSince(node, previous, sample)
+	}
+
+	return sample
+}
+
+// tpsSince - calculate the TPS for the blocks produced between two samples
+func tpsSince(node string, previous *Sample, current Sample) float64 {
+	elapsed := current.Time.Sub(previous.Time).Seconds()
+	if elapsed <= 0 || current.BlockNumber <= previous.BlockNumber {
+		return 0.0
+	}
+
+	txCount := uint64(0)
+	for blockNumber := previous.BlockNumber + 1; blockNumber <= current.BlockNumber; blockNumber++ {
+		count, err := sdkRPC.GetTransactionCountByBlockNumber(blockNumber, node)
+		if err == nil {
+			txCount += count
+		}
+	}
+
+	return float64(txCount) / elapsed
+}
+
+func chartSamples(shard uint32, samples []Sample) error {
+	xAxisData := []time.Time{}
+	pendingData := []float64{}
+	pendingStakingData := []float64{}
+	tpsData := []float64{}
+
+	for _, sample := range samples {
+		xAxisData = append(xAxisData, sample.Time)
+		pendingData = append(pendingData, float64(sample.Pending))
+		pendingStakingData = append(pendingStakingData, float64(sample.PendingStaking))
+		tpsData = append(tpsData, sample.TPS)
+	}
+
+	fileName := fmt.Sprintf("pool/shard-%d-%s.png", shard, utils.FormattedTimeString(samples[0].Time))
+
+	return charts.GenerateMultiTimeSeriesChart(
+		fileName,
+		"Time (UTC)",
+		"Pending Transactions",
+		"Transactions Per Second",
+		xAxisData,
+		[]charts.Series{
+			{Title: "Pending Transactions", YValues: pendingData},
+			{Title: "Pending Staking Transactions", YValues: pendingStakingData},
+			{Title: "Transactions Per Second", YValues: tpsData, Secondary: true},
+		},
+		[]string{
+			"Harmony Transaction Pool Report",
+			fmt.Sprintf("Network: %s", config.Configuration.Network.Name),
+			fmt.Sprintf("Shard: %d", shard),
+			fmt.Sprintf("Blocks: %d - %d", samples[0].BlockNumber, samples[len(samples)-1].BlockNumber),
+		},
+	)
+}
+
+func exportToCSV(shard uint32, samples []Sample) (string, error) {
+	fileName := fmt.Sprintf("pool/shard-%d-%s-UTC.csv", shard, utils.FormattedTimeString(time.Now().UTC()))
+
+	rows := [][]string{
+		{
+			"Time",
+			"Block Number",
+			"Pending Transactions",
+			"Pending Staking Transactions",
+			"TPS",
+		},
+	}
+
+	for _, sample := range samples {
+		rows = append(rows, []string{
+			sample.Time.Format(time.RFC3339),
+			fmt.Sprintf("%d", sample.BlockNumber),
+			fmt.Sprintf("%d", sample.Pending),
+			fmt.Sprintf("%d", sample.PendingStaking),
+			fmt.Sprintf("%f", sample.TPS),
+		})
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
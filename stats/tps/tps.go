@@ -3,8 +3,6 @@ package tps
 import (
 	"fmt"
 	"sort"
-	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/SebastianJ/harmony-stats/blocks"
@@ -27,7 +25,13 @@ func AnalyzeTPS() error {
 
 	for _, shard := range targetShards {
 		waitGroup.Add(1)
-		go analyzeTPSForShard(shard, &waitGroup)
+		go func(shard uint32) {
+			defer waitGroup.Done()
+
+			if err := analyzeTPSForShard(shard); err != nil {
+				fmt.Printf("Failed to analyze tps for shard %d - error: %s\n", shard, err.Error())
+			}
+		}(shard)
 	}
 
 	waitGroup.Wait()
@@ -35,78 +39,35 @@ func AnalyzeTPS() error {
 	return nil
 }
 
-func analyzeTPSForShard(shard uint32, parentWaitGroup *sync.WaitGroup) error {
-	defer parentWaitGroup.Done()
-
+func analyzeTPSForShard(shard uint32) error {
 	node := config.Configuration.Network.API.Shards[shard].Node
 	fmt.Printf("Checking tx counts for shard %d\n", shard)
 
-	fromBlockNumber := uint64(0)
-	toBlockNumber := uint64(0)
-	latestBlockNumber, err := sdkRPC.GetCurrentBlockNumber(node)
+	fromBlockNumber, toBlockNumber, err := blocks.Range(node, config.TPSArgs.From, config.TPSArgs.To, config.TPSArgs.Count)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("latestBlockNumber is now: %d\n", latestBlockNumber)
-
-	if config.TPSArgs.From >= 0 && config.TPSArgs.To >= 0 {
-		fromBlockNumber = uint64(config.TPSArgs.From)
-		toBlockNumber = uint64(config.TPSArgs.To)
-	} else if config.TPSArgs.From >= 0 && config.TPSArgs.To < 0 {
-		fromBlockNumber = uint64(config.TPSArgs.From)
-		toBlockNumber = latestBlockNumber
-	} else if config.TPSArgs.From >= 0 && config.TPSArgs.Count >= 0 {
-		fromBlockNumber = uint64(config.TPSArgs.From)
-		toBlockNumber = fromBlockNumber + uint64(config.TPSArgs.Count)
-	} else if config.TPSArgs.To >= 0 && config.TPSArgs.Count >= 0 {
-		toBlockNumber = uint64(config.TPSArgs.To)
-		fromBlockNumber = toBlockNumber - uint64(config.TPSArgs.Count)
-	} else if config.TPSArgs.From < 0 && config.TPSArgs.To < 0 && config.TPSArgs.Count > 0 {
-		toBlockNumber = latestBlockNumber
-		fromBlockNumber = toBlockNumber - uint64(config.TPSArgs.Count)
-	} else {
-		fromBlockNumber = 0
-		toBlockNumber = latestBlockNumber
-	}
-
 	fmt.Printf("Starting to analyze blocks from block #%d to block #%d for shard %d ...\n", fromBlockNumber, toBlockNumber, shard)
 
-	currentBlockNumber := fromBlockNumber
-	totalChecked := 0
-	var innerWaitGroup sync.WaitGroup
-	blockResults := make(chan blocks.BlockResult, toBlockNumber)
-
-	for {
-		if currentBlockNumber < toBlockNumber {
-			innerWaitGroup.Add(1)
-
-			go blockStatistics(node, shard, currentBlockNumber, blockResults, &innerWaitGroup)
-			totalChecked++
-
-			// Wait every <concurrency count> number of blocks before proceeding to queue up more goroutines
-			if currentBlockNumber%uint64(config.Configuration.Concurrency) == 0 {
-				innerWaitGroup.Wait()
-			}
+	var mutex sync.Mutex
+	results := []blocks.BlockResult{}
 
-			currentBlockNumber++
-		} else {
-			break
+	failed := blocks.Fetch(fromBlockNumber, toBlockNumber, func(blockNumber uint64) error {
+		blockResult, err := blockStatistics(node, shard, blockNumber)
+		if err != nil {
+			return err
 		}
-	}
-
-	innerWaitGroup.Wait()
 
-	close(blockResults)
+		fmt.Printf("Tx Count for block number %d in shard %d is: %d - TPS is %f\n", blockResult.BlockNumber, blockResult.ShardID, blockResult.TxCount, blockResult.TPS)
 
-	results := []blocks.BlockResult{}
+		mutex.Lock()
+		results = append(results, blockResult)
+		mutex.Unlock()
 
-	for blockResult := range blockResults {
-		if blockResult.Successful {
-			fmt.Printf("Tx Count for block number %d in shard %d is: %d - TPS is %f\n", blockResult.BlockNumber, blockResult.ShardID, blockResult.TxCount, blockResult.TPS)
-			results = append(results, blockResult)
-		}
-	}
+		return nil
+	})
+	blocks.ReportFailures(shard, failed)
 
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].BlockNumber < results[j].BlockNumber
@@ -136,47 +97,31 @@ func analyzeTPSForShard(shard uint32, parentWaitGroup *sync.WaitGroup) error {
 	return nil
 }
 
-func blockStatistics(node string, shard uint32, blockNumber uint64, blockResults chan<- blocks.BlockResult, waitGroup *sync.WaitGroup) {
-	defer waitGroup.Done()
-	blockResult := blocks.BlockResult{}
-
+func blockStatistics(node string, shard uint32, blockNumber uint64) (blocks.BlockResult, error) {
 	fmt.Printf("Checking tx count and tps for block number %d in shard %d (node: %s) ...\n", blockNumber, shard, node)
 
 	txCount, err := sdkRPC.GetTransactionCountByBlockNumber(blockNumber, node)
-	if err == nil {
-		tps := 0.0
-		if txCount > 0 {
-			tps = float64(txCount) / float64(config.TPSArgs.BlockTime)
-		}
+	if err != nil {
+		return blocks.BlockResult{}, err
+	}
 
-		blockResult.Successful = true
-		blockResult.ShardID = shard
-		blockResult.BlockNumber = blockNumber
-		blockResult.TxCount = txCount
-		blockResult.TPS = tps
-	} else {
-		blockResult.Successful = false
+	tps := 0.0
+	if txCount > 0 {
+		tps = float64(txCount) / float64(config.TPSArgs.BlockTime)
 	}
 
-	blockResults <- blockResult
+	return blocks.BlockResult{
+		Successful:  true,
+		ShardID:     shard,
+		BlockNumber: blockNumber,
+		TxCount:     txCount,
+		TPS:         tps,
+	}, nil
 }
 
-func setTargetShards() error {
-	shardFlag := strings.ToLower(config.TPSArgs.Shard)
-
-	if shardFlag == "all" {
-		for i := uint32(0); i < uint32(config.Configuration.Network.API.ShardCount); i++ {
-			targetShards = append(targetShards, i)
-		}
-	} else {
-		shard, err := strconv.Atoi(shardFlag)
-		if err != nil {
-			return err
-		}
-		targetShards = append(targetShards, uint32(shard))
-	}
-
-	return nil
+func setTargetShards() (err error) {
+	targetShards, err = blocks.TargetShards(config.TPSArgs.Shard)
+	return err
 }
 
 func convertBlockResultsToGraphData(blockResults []blocks.BlockResult) (xValues []float64, yValues []float64) {
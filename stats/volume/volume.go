@@ -0,0 +1,234 @@
+package volume
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/blocks"
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/utils"
+	"github.com/elliotchance/orderedmap"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+var (
+	targetShards []uint32
+	timeFormat   string = "2006-01-02"
+)
+
+// AnalyzeVolume - analyze the transferred ONE volume per block and per day
+func AnalyzeVolume() error {
+	if err := setTargetShards(); err != nil {
+		return err
+	}
+
+	var waitGroup sync.WaitGroup
+
+	for _, shard := range targetShards {
+		waitGroup.Add(1)
+		go analyzeVolumeForShard(shard, &waitGroup)
+	}
+
+	waitGroup.Wait()
+
+	return nil
+}
+
+func analyzeVolumeForShard(shard uint32, parentWaitGroup *sync.WaitGroup) {
+	defer parentWaitGroup.Done()
+
+	if err := volumeForShard(shard); err != nil {
+		fmt.Printf("Failed to analyze volume for shard %d - error: %s\n", shard, err.Error())
+	}
+}
+
+func volumeForShard(shard uint32) error {
+	node := config.Configuration.Network.API.Shards[shard].Node
+	fmt.Printf("Checking transferred volume for shard %d\n", shard)
+
+	fromBlockNumber, toBlockNumber, err := blocks.Range(node, config.VolumeArgs.From, config.VolumeArgs.To, config.VolumeArgs.Count)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Starting to analyze blocks from block #%d to block #%d for shard %d ...\n", fromBlockNumber, toBlockNumber, shard)
+
+	results := retrieveBlockResults(node, shard, fromBlockNumber, toBlockNumber)
+
+	totalVolume := numeric.ZeroDec()
+	totalTxCount := uint64(0)
+	for _, blockResult := range results {
+		totalVolume = totalVolume.Add(blockResult.Volume)
+		totalTxCount += blockResult.TxCount
+	}
+
+	fmt.Printf("Total transferred volume for shard %d between block #%d and block #%d: %f ONE (%d transactions)\n", shard, fromBlockNumber, toBlockNumber, totalVolume, totalTxCount)
+
+	volumePerDate := identifyVolumePerDate(results)
+
+	xAxisData := []time.Time{}
+	yAxisData := []float64{}
+	for el := volumePerDate.Front(); el != nil; el = el.Next() {
+		dateString := el.Key.(string)
+		dailyVolume := el.Value.(numeric.Dec)
+
+		date, err := time.Parse(timeFormat, dateString)
+		if err != nil {
+			return err
+		}
+
+		xAxisData = append(xAxisData, date)
+		yAxisData = append(yAxisData, utils.DecToFloat(dailyVolume))
+
+		fmt.Printf("Date %s - transferred volume for shard %d: %f ONE\n", dateString, shard, dailyVolume)
+	}
+
+	fileName := fmt.Sprintf("volume/shard-%d-block-%d-to-%d-daily.png", shard, fromBlockNumber, toBlockNumber)
+	err = charts.GenerateTimeSeriesChart(
+		fileName,
+		"Transferred Volume (ONE)",
+		"Date",
+		"ONE",
+		xAxisData,
+		yAxisData,
+		[]string{
+			"Harmony Transaction Volume Report",
+			fmt.Sprintf("Network: %s", config.Configuration.Network.Name),
+			fmt.Sprintf("Shard: %d", shard),
+			fmt.Sprintf("Blocks: %d - %d", fromBlockNumber, toBlockNumber),
+			fmt.Sprintf("Total: %s ONE", totalVolume.TruncateInt().String()),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportBlocksToCSV(shard, fromBlockNumber, toBlockNumber, results)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported block volume data for shard %d to %s\n", shard, csvPath)
+		}
+
+		csvPath, err = exportDailyToCSV(shard, fromBlockNumber, toBlockNumber, volumePerDate)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported daily volume data for shard %d to %s\n", shard, csvPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+func retrieveBlockResults(node string, shard uint32, fromBlockNumber uint64, toBlockNumber uint64) []blocks.BlockResult {
+	shardBlocks, failed := blocks.FullBlocks(node, fromBlockNumber, toBlockNumber)
+	blocks.ReportFailures(shard, failed)
+
+	results := []blocks.BlockResult{}
+	for _, block := range shardBlocks {
+		volume := numeric.ZeroDec()
+		for _, tx := range block.Transactions {
+			volume = volume.Add(tx.Value)
+		}
+
+		results = append(results, blocks.BlockResult{
+			Successful:  true,
+			ShardID:     shard,
+			BlockNumber: block.BlockNumber,
+			Timestamp:   block.Timestamp,
+			TxCount:     uint64(len(block.Transactions)),
+			Volume:      volume,
+		})
+	}
+
+	return results
+}
+
+func identifyVolumePerDate(blockResults []blocks.BlockResult) *orderedmap.OrderedMap {
+	dateVolumes := orderedmap.NewOrderedMap()
+	for _, blockResult := range blockResults {
+		if blockResult.Timestamp.IsZero() {
+			continue
+		}
+
+		date := blockResult.Timestamp.Format(timeFormat)
+
+		volume := blockResult.Volume
+		if value, exists := dateVolumes.Get(date); exists {
+			volume = value.(numeric.Dec).Add(volume)
+		}
+
+		dateVolumes.Set(date, volume)
+	}
+
+	return dateVolumes
+}
+
+func exportBlocksToCSV(shard uint32, fromBlockNumber uint64, toBlockNumber uint64, blockResults []blocks.BlockResult) (string, error) {
+	fileName := fmt.Sprintf("volume/shard-%d-block-%d-to-%d-blocks-%s-UTC.csv", shard, fromBlockNumber, toBlockNumber, utils.FormattedTimeString(time.Now().UTC()))
+
+	rows := [][]string{
+		{
+			"Block Number",
+			"Date",
+			"Transaction Count",
+			"Volume (ONE)",
+		},
+	}
+
+	for _, blockResult := range blockResults {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", blockResult.BlockNumber),
+			blockResult.Timestamp.Format(timeFormat),
+			fmt.Sprintf("%d", blockResult.TxCount),
+			fmt.Sprintf("%f", blockResult.Volume),
+		})
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
+
+func exportDailyToCSV(shard uint32, fromBlockNumber uint64, toBlockNumber uint64, volumePerDate *orderedmap.OrderedMap) (string, error) {
+	fileName := fmt.Sprintf("volume/shard-%d-block-%d-to-%d-daily-%s-UTC.csv", shard, fromBlockNumber, toBlockNumber, utils.FormattedTimeString(time.Now().UTC()))
+
+	rows := [][]string{
+		{
+			"Date",
+			"Volume (ONE)",
+		},
+	}
+
+	totalVolume := numeric.ZeroDec()
+	for el := volumePerDate.Front(); el != nil; el = el.Next() {
+		dailyVolume := el.Value.(numeric.Dec)
+		totalVolume = totalVolume.Add(dailyVolume)
+		rows = append(rows, []string{el.Key.(string), fmt.Sprintf("%f", dailyVolume)})
+	}
+
+	rows = append(rows, []string{"Total", fmt.Sprintf("%f", totalVolume)})
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
+
+func setTargetShards() (err error) {
+	targetShards, err = blocks.TargetShards(config.VolumeArgs.Shard)
+	return err
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	goSdkAddress "github.com/harmony-one/go-sdk/pkg/address"
+)
+
+// IsHexAddress - checks if a given address is a 0x prefixed hex address
+func IsHexAddress(address string) bool {
+	if !strings.HasPrefix(strings.ToLower(address), "0x") {
+		return false
+	}
+
+	bytes, err := hex.DecodeString(address[2:])
+	return err == nil && len(bytes) == goSdkAddress.AddressLength
+}
+
+// ToHexAddress - converts a bech32 (one1...) address to its checksummed hex (0x...) representation
+func ToHexAddress(address string) (string, error) {
+	if IsHexAddress(address) {
+		return goSdkAddress.Parse(address).Hex(), nil
+	}
+
+	converted, err := goSdkAddress.Bech32ToAddress(address)
+	if err != nil {
+		return "", err
+	}
+
+	return converted.Hex(), nil
+}
+
+// ToBech32Address - converts a hex (0x...) address to its bech32 (one1...) representation
+func ToBech32Address(address string) (string, error) {
+	if !IsHexAddress(address) {
+		if _, err := goSdkAddress.Bech32ToAddress(address); err != nil {
+			return "", fmt.Errorf("%s is neither a valid hex nor a valid bech32 address", address)
+		}
+		return address, nil
+	}
+
+	return goSdkAddress.ToBech32(goSdkAddress.Parse(address)), nil
+}
+
+// FormatAddress - formats a bech32 address using the given address format
+// The "both" format returns the bech32 address, the hex address is meant to be exported in a separate column or field
+// Addresses that can't be converted are returned as is
+func FormatAddress(address string, format string) string {
+	if strings.ToLower(format) == "hex" {
+		if hexAddress, err := ToHexAddress(address); err == nil {
+			return hexAddress
+		}
+	}
+
+	return address
+}
@@ -0,0 +1,62 @@
+package utils
+
+import "testing"
+
+func TestFormatAddress(t *testing.T) {
+	bech32Address := "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"
+	hexAddress, err := ToHexAddress(bech32Address)
+	if err != nil {
+		t.Fatalf("ToHexAddress(%q) returned an unexpected error: %s", bech32Address, err.Error())
+	}
+
+	tests := []struct {
+		address  string
+		format   string
+		expected string
+	}{
+		{bech32Address, "one", bech32Address},
+		{bech32Address, "", bech32Address},
+		{bech32Address, "hex", hexAddress},
+		{bech32Address, "HEX", hexAddress},
+		// The hex address is exported separately when using both formats
+		{bech32Address, "both", bech32Address},
+		// Addresses that can't be converted are returned as is
+		{"invalid", "hex", "invalid"},
+	}
+
+	for _, test := range tests {
+		if actual := FormatAddress(test.address, test.format); actual != test.expected {
+			t.Errorf("FormatAddress(%q, %q) = %q, expected %q", test.address, test.format, actual, test.expected)
+		}
+	}
+}
+
+func TestAddressConversions(t *testing.T) {
+	bech32Address := "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"
+
+	hexAddress, err := ToHexAddress(bech32Address)
+	if err != nil {
+		t.Fatalf("ToHexAddress(%q) returned an unexpected error: %s", bech32Address, err.Error())
+	}
+
+	if !IsHexAddress(hexAddress) {
+		t.Errorf("IsHexAddress(%q) = false, expected true", hexAddress)
+	}
+
+	if IsHexAddress(bech32Address) {
+		t.Errorf("IsHexAddress(%q) = true, expected false", bech32Address)
+	}
+
+	converted, err := ToBech32Address(hexAddress)
+	if err != nil {
+		t.Fatalf("ToBech32Address(%q) returned an unexpected error: %s", hexAddress, err.Error())
+	}
+
+	if converted != bech32Address {
+		t.Errorf("ToBech32Address(%q) = %q, expected %q", hexAddress, converted, bech32Address)
+	}
+
+	if _, err := ToBech32Address("one1invalid"); err == nil {
+		t.Errorf("ToBech32Address(%q) didn't return an error", "one1invalid")
+	}
+}
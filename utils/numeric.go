@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"strconv"
+
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// DecToFloat - converts a numeric.Dec to a float64 - only meant for charting, not for precise calculations
+func DecToFloat(dec numeric.Dec) float64 {
+	if dec.IsNil() {
+		return 0.0
+	}
+
+	float, err := strconv.ParseFloat(dec.String(), 64)
+	if err != nil {
+		return 0.0
+	}
+
+	return float
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"math"
+	"sort"
+)
+
+// Percentile - calculates the given percentile (0-100) of a set of values using linear interpolation
+func Percentile(values []float64, percentile float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := (percentile / 100.0) * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	if lower == upper {
+		return sorted[lower]
+	}
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// Mean - calculates the arithmetic mean of a set of values
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+// MinMax - returns the smallest and the largest value of a set of values
+func MinMax(values []float64) (min float64, max float64) {
+	if len(values) == 0 {
+		return 0.0, 0.0
+	}
+
+	min, max = values[0], values[0]
+	for _, value := range values {
+		min = math.Min(min, value)
+		max = math.Max(max, value)
+	}
+
+	return min, max
+}
+
+// Gini - calculates the Gini coefficient (0 = perfect equality, 1 = maximal inequality) of a set of non-negative values
+func Gini(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	weightedSum := 0.0
+	for index, value := range sorted {
+		sum += value
+		weightedSum += float64(index+1) * value
+	}
+
+	if sum == 0 {
+		return 0.0
+	}
+
+	count := float64(len(sorted))
+
+	return (2*weightedSum)/(count*sum) - (count+1)/count
+}
+
+// HHI - calculates the Herfindahl-Hirschman index (0 - 10,000) of a set of market shares expressed as fractions (0 - 1)
+func HHI(shares []float64) float64 {
+	hhi := 0.0
+	for _, share := range shares {
+		hhi += math.Pow(share*100.0, 2)
+	}
+
+	return hhi
+}
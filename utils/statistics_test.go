@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+const tolerance = 1e-9
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		values     []float64
+		percentile float64
+		expected   float64
+	}{
+		{[]float64{}, 50, 0},
+		{[]float64{7}, 50, 7},
+		{[]float64{7}, 99, 7},
+		{[]float64{1, 2, 3, 4, 5}, 0, 1},
+		{[]float64{1, 2, 3, 4, 5}, 50, 3},
+		{[]float64{1, 2, 3, 4, 5}, 100, 5},
+		{[]float64{5, 1, 4, 2, 3}, 50, 3},
+		{[]float64{1, 2, 3, 4}, 50, 2.5},
+		{[]float64{1, 2, 3, 4}, 90, 3.7},
+		{[]float64{10, 20}, 95, 19.5},
+	}
+
+	for _, test := range tests {
+		if actual := Percentile(test.values, test.percentile); math.Abs(actual-test.expected) > tolerance {
+			t.Errorf("Percentile(%v, %v) = %v, expected %v", test.values, test.percentile, actual, test.expected)
+		}
+	}
+}
+
+func TestPercentileDoesNotModifyValues(t *testing.T) {
+	values := []float64{3, 1, 2}
+	Percentile(values, 50)
+
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Errorf("Percentile modified the supplied values: %v", values)
+	}
+}
+
+func TestMeanAndMinMax(t *testing.T) {
+	tests := []struct {
+		values []float64
+		mean   float64
+		min    float64
+		max    float64
+	}{
+		{[]float64{}, 0, 0, 0},
+		{[]float64{4}, 4, 4, 4},
+		{[]float64{3, -1, 10, 4}, 4, -1, 10},
+	}
+
+	for _, test := range tests {
+		if actual := Mean(test.values); math.Abs(actual-test.mean) > tolerance {
+			t.Errorf("Mean(%v) = %v, expected %v", test.values, actual, test.mean)
+		}
+
+		if min, max := MinMax(test.values); min != test.min || max != test.max {
+			t.Errorf("MinMax(%v) = %v, %v, expected %v, %v", test.values, min, max, test.min, test.max)
+		}
+	}
+}
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		values   []float64
+		expected float64
+	}{
+		{[]float64{}, 0},
+		{[]float64{0, 0, 0}, 0},
+		{[]float64{5}, 0},
+		{[]float64{1, 1, 1, 1}, 0},
+		{[]float64{1, 2, 3, 4}, 0.25},
+		{[]float64{4, 3, 2, 1}, 0.25},
+		// A single holder of everything results in the maximal coefficient for the sample size: (n-1)/n
+		{[]float64{0, 0, 0, 1}, 0.75},
+	}
+
+	for _, test := range tests {
+		if actual := Gini(test.values); math.Abs(actual-test.expected) > tolerance {
+			t.Errorf("Gini(%v) = %v, expected %v", test.values, actual, test.expected)
+		}
+	}
+}
+
+func TestHHI(t *testing.T) {
+	tests := []struct {
+		shares   []float64
+		expected float64
+	}{
+		{[]float64{}, 0},
+		{[]float64{1}, 10000},
+		{[]float64{0.5, 0.5}, 5000},
+		{[]float64{0.25, 0.25, 0.25, 0.25}, 2500},
+		{[]float64{0.6, 0.3, 0.1}, 4600},
+	}
+
+	for _, test := range tests {
+		if actual := HHI(test.shares); math.Abs(actual-test.expected) > 1e-6 {
+			t.Errorf("HHI(%v) = %v, expected %v", test.shares, actual, test.expected)
+		}
+	}
+}
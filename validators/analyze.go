@@ -2,6 +2,8 @@ package validators
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -9,39 +11,101 @@ import (
 	"github.com/SebastianJ/harmony-stats/config"
 	"github.com/SebastianJ/harmony-stats/export"
 	"github.com/SebastianJ/harmony-stats/utils"
-	sdkDelegation "github.com/harmony-one/go-lib/staking/delegation"
 	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
 	"github.com/harmony-one/harmony/numeric"
 )
 
 // ValidatorResult - wrapper for validator info
 type ValidatorResult struct {
-	Result  sdkValidator.RPCValidatorResult
-	Balance numeric.Dec
-	Error   error
+	Result        sdkValidator.RPCValidatorResult
+	Balance       numeric.Dec
+	ShardBalances map[uint32]numeric.Dec
+	Uptime        Uptime
+	Status        ValidatorStatus
+	Error         error
+}
+
+// validatorExport - the json representation of an analyzed validator
+type validatorExport struct {
+	Name            string             `json:"name"`
+	Address         string             `json:"address"`
+	HexAddress      string             `json:"hex-address,omitempty"`
+	Identity        string             `json:"identity"`
+	BLSKeys         []string           `json:"bls-public-keys"`
+	SelfDelegation  float64            `json:"self-delegation"`
+	TotalDelegation float64            `json:"total-delegation"`
+	LifetimeRewards float64            `json:"lifetime-rewards"`
+	CommissionRate  float64            `json:"commission-rate"`
+	MaxRate         float64            `json:"max-rate"`
+	MaxChangeRate   float64            `json:"max-change-rate"`
+	WalletBalance   *float64           `json:"wallet-balance,omitempty"`
+	ShardBalances   map[uint32]float64 `json:"shard-balances,omitempty"`
+	Uptime          Uptime             `json:"uptime"`
+	Status          ValidatorStatus    `json:"status"`
+	Error           string             `json:"error,omitempty"`
 }
 
 // Analyze - analyze validators
 func Analyze() error {
 	fmt.Printf("Looking up validator statistics - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
 
-	validators, err := Filtered()
+	// Compile the filter before looking up validators so that invalid expressions fail fast
+	filter, err := CompileFilter()
 	if err != nil {
 		return err
 	}
 
+	validatorInformation, err := allInformation()
+	if err != nil {
+		return err
+	}
+
+	allValidatorResults := []ValidatorResult{}
 	validatorResults := []ValidatorResult{}
-	for _, rpcValidatorResult := range validators {
-		validatorResults = append(validatorResults, ValidatorResult{Result: rpcValidatorResult})
+	for _, information := range validatorInformation {
+		validatorResult := ValidatorResult{
+			Result: information.RPCValidatorResult,
+			Uptime: CalculateUptime(information.RPCValidatorResult),
+			Status: DetermineStatus(information.RPCValidatorResult, information.Booted()),
+		}
+
+		allValidatorResults = append(allValidatorResults, validatorResult)
+		if matchesFilters(information.RPCValidatorResult, filter) {
+			validatorResults = append(validatorResults, validatorResult)
+		}
 	}
 
 	if config.ValidatorArgs.Balances {
 		validatorResults = lookupValidatorBalances(validatorResults)
+		mergeBalances(allValidatorResults, validatorResults)
 	}
 
-	fmt.Printf("Total checked number of validators: %d\n", len(validatorResults))
+	fmt.Printf("Total checked number of validators: %d\n", len(allValidatorResults))
 	fmt.Printf("Total number of validators matching filter: %d\n", len(validatorResults))
 
+	belowThreshold := 0
+	for _, validatorResult := range validatorResults {
+		if validatorResult.Uptime.BelowThreshold {
+			belowThreshold++
+			fmt.Printf("Validator %s (%s) is below the availability threshold - current epoch uptime: %.2f%% (%d/%d), lifetime uptime: %.2f%%\n",
+				validatorResult.Result.Validator.Name,
+				validatorResult.Result.Validator.Address,
+				validatorResult.Uptime.CurrentEpochUptime,
+				validatorResult.Uptime.CurrentEpochSigned,
+				validatorResult.Uptime.CurrentEpochToSign,
+				validatorResult.Uptime.LifetimeUptime,
+			)
+		}
+	}
+	fmt.Printf("Total number of validators below the availability threshold (%.2f%%): %d\n", uptimeThreshold(), belowThreshold)
+
+	// Snapshots always contain every validator so that snapshots taken using different filters can be compared
+	snapshotPath, err := SaveSnapshot(allValidatorResults)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Successfully saved validator snapshot to %s\n", snapshotPath)
+
 	switch strings.ToLower(config.Configuration.Export.Format) {
 	case "csv":
 		csvPath, err := exportToCSV(validatorResults)
@@ -50,53 +114,116 @@ func Analyze() error {
 		} else if csvPath != "" {
 			fmt.Printf("Successfully exported validator data to %s\n", csvPath)
 		}
-	//case "json":
+	case "json":
+		jsonPath, err := exportToJSON(validatorResults)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported validator data to %s\n", jsonPath)
+		}
 	default:
 	}
 
 	return nil
 }
 
+// lookupValidatorBalances - look up the wallet balances of all validators, <concurrency> wallets at a time
+// Results are written back to their original index so that the order established by All() is preserved
 func lookupValidatorBalances(validatorResults []ValidatorResult) []ValidatorResult {
-	validatorsChannel := make(chan ValidatorResult, len(validatorResults))
+	concurrency := config.Configuration.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
 	var waitGroup sync.WaitGroup
 
-	for index, validatorResult := range validatorResults {
+	for index := range validatorResults {
 		waitGroup.Add(1)
-		go lookupValidatorBalance(validatorResult, validatorsChannel, &waitGroup)
+		go lookupValidatorBalance(&validatorResults[index], &waitGroup)
 
-		// Wait every <concurrency count> number of blocks before proceeding to queue up more goroutines
-		if index%config.Configuration.Concurrency == 0 {
+		// Wait every <concurrency count> number of wallets before proceeding to queue up more goroutines
+		if (index+1)%concurrency == 0 {
 			waitGroup.Wait()
 		}
 	}
 
 	waitGroup.Wait()
-	close(validatorsChannel)
 
-	validatorResults = []ValidatorResult{}
+	failed := []ValidatorResult{}
+	for _, validatorResult := range validatorResults {
+		if validatorResult.Error != nil {
+			failed = append(failed, validatorResult)
+		}
+	}
 
-	for validatorResult := range validatorsChannel {
-		validatorResults = append(validatorResults, validatorResult)
+	if len(failed) > 0 {
+		fmt.Printf("Failed to look up the balance for %d of %d validator wallets:\n", len(failed), len(validatorResults))
+		for _, validatorResult := range failed {
+			fmt.Printf("Validator %s (%s) - error: %s\n", validatorResult.Result.Validator.Name, validatorResult.Result.Validator.Address, validatorResult.Error.Error())
+		}
 	}
 
 	return validatorResults
 }
 
-func lookupValidatorBalance(validatorResult ValidatorResult, validatorsChannel chan<- ValidatorResult, waitGroup *sync.WaitGroup) {
+// mergeBalances - copy looked up balances (and lookup errors) of a subset of validators back to the full set of validators
+func mergeBalances(allValidatorResults []ValidatorResult, validatorResults []ValidatorResult) {
+	lookup := make(map[string]ValidatorResult)
+	for _, validatorResult := range validatorResults {
+		lookup[validatorResult.Result.Validator.Address] = validatorResult
+	}
+
+	for index := range allValidatorResults {
+		if validatorResult, exists := lookup[allValidatorResults[index].Result.Validator.Address]; exists {
+			allValidatorResults[index].Balance = validatorResult.Balance
+			allValidatorResults[index].ShardBalances = validatorResult.ShardBalances
+			allValidatorResults[index].Error = validatorResult.Error
+		}
+	}
+}
+
+func lookupValidatorBalance(validatorResult *ValidatorResult, waitGroup *sync.WaitGroup) {
 	defer waitGroup.Done()
 
 	fmt.Printf("Looking up balance for validator wallet %s\n", validatorResult.Result.Validator.Address)
 
-	totalBalance, err := config.Configuration.Network.API.GetTotalBalance(validatorResult.Result.Validator.Address)
+	shardBalances, err := config.Configuration.Network.API.GetAllShardBalances(validatorResult.Result.Validator.Address)
 	if err != nil {
 		validatorResult.Error = err
-		validatorsChannel <- validatorResult
 		return
 	}
 
+	totalBalance := numeric.ZeroDec()
+	for _, balance := range shardBalances {
+		totalBalance = totalBalance.Add(balance)
+	}
+
+	validatorResult.ShardBalances = shardBalances
 	validatorResult.Balance = totalBalance
-	validatorsChannel <- validatorResult
+}
+
+// balanceShardIDs - the shard ids to include per shard balance columns for, sorted in ascending order
+func balanceShardIDs(validatorResults []ValidatorResult) []uint32 {
+	shardIDs := []uint32{}
+	for shardID := 0; shardID < config.Configuration.Network.API.ShardCount; shardID++ {
+		shardIDs = append(shardIDs, uint32(shardID))
+	}
+
+	// Fall back to the shards present in the results if the shard count isn't known
+	if len(shardIDs) == 0 {
+		seen := make(map[uint32]bool)
+		for _, validatorResult := range validatorResults {
+			for shardID := range validatorResult.ShardBalances {
+				if !seen[shardID] {
+					seen[shardID] = true
+					shardIDs = append(shardIDs, shardID)
+				}
+			}
+		}
+		sort.Slice(shardIDs, func(i, j int) bool { return shardIDs[i] < shardIDs[j] })
+	}
+
+	return shardIDs
 }
 
 func exportToCSV(validatorResults []ValidatorResult) (string, error) {
@@ -104,19 +231,42 @@ func exportToCSV(validatorResults []ValidatorResult) (string, error) {
 
 	rows := [][]string{}
 
-	headers := []string{
-		"Name",
-		"Address",
+	headers := []string{"Name"}
+	headers = append(headers, addressHeaders("Address")...)
+	headers = append(headers,
 		"Identity",
 		"BLS Key Count",
 		"BLS Keys",
 		"Self Delegation",
 		"Total Delegation",
 		"Lifetime Rewards",
-	}
-
+		"Commission Rate",
+		"Max Rate",
+		"Max Change Rate",
+		"Current Epoch Signed",
+		"Current Epoch To Sign",
+		"Current Epoch Uptime (%)",
+		"Lifetime Signed",
+		"Lifetime To Sign",
+		"Lifetime Uptime (%)",
+		"Below Availability Threshold",
+		"Status",
+		"Elected",
+		"EPoS Status",
+		"Eligibility Status",
+		"Booted Status",
+	)
+
+	shardIDs := []uint32{}
 	if config.ValidatorArgs.Balances {
 		headers = append(headers, "Wallet Balance")
+
+		shardIDs = balanceShardIDs(validatorResults)
+		for _, shardID := range shardIDs {
+			headers = append(headers, fmt.Sprintf("Shard %d Balance", shardID))
+		}
+
+		headers = append(headers, "Balance Error")
 	}
 
 	rows = append(rows, headers)
@@ -124,27 +274,55 @@ func exportToCSV(validatorResults []ValidatorResult) (string, error) {
 	if len(validatorResults) > 0 {
 		for _, validatorResult := range validatorResults {
 			validator := validatorResult.Result.Validator
-			var selfDelegation sdkDelegation.DelegationInfo
-			for _, delegation := range validator.Delegations {
-				if delegation.DelegatorAddress == validator.Address {
-					selfDelegation = delegation
-					break
-				}
-			}
+			selfDelegation := selfDelegation(validator)
 
-			row := []string{
-				validator.Name,
-				validator.Address,
+			row := []string{validator.Name}
+			row = append(row, addressColumns(formatAddress(validator.Address), hexAddress(validator.Address))...)
+			row = append(row,
 				validator.Identity,
 				fmt.Sprintf("%d", len(validator.BLSPublicKeys)),
 				strings.Join(validator.BLSPublicKeys[:], "\n"),
 				fmt.Sprintf("%f", selfDelegation.Amount),
 				fmt.Sprintf("%f", validatorResult.Result.TotalDelegation),
 				fmt.Sprintf("%f", validatorResult.Result.Lifetime.RewardAccumulated),
-			}
+				fmt.Sprintf("%f", validator.Rate),
+				fmt.Sprintf("%f", validator.MaxRate),
+				fmt.Sprintf("%f", validator.MaxChangeRate),
+				fmt.Sprintf("%d", validatorResult.Uptime.CurrentEpochSigned),
+				fmt.Sprintf("%d", validatorResult.Uptime.CurrentEpochToSign),
+				fmt.Sprintf("%.2f", validatorResult.Uptime.CurrentEpochUptime),
+				fmt.Sprintf("%d", validatorResult.Uptime.LifetimeSigned),
+				fmt.Sprintf("%d", validatorResult.Uptime.LifetimeToSign),
+				fmt.Sprintf("%.2f", validatorResult.Uptime.LifetimeUptime),
+				strconv.FormatBool(validatorResult.Uptime.BelowThreshold),
+				validatorResult.Status.Status,
+				strconv.FormatBool(validatorResult.Status.Elected),
+				validatorResult.Status.EPoSStatus,
+				validatorResult.Status.EligibilityStatus,
+				validatorResult.Status.BootedStatus,
+			)
+
+			// Wallets whose balance couldn't be looked up get empty balance cells so that the columns stay aligned
+			if config.ValidatorArgs.Balances {
+				if validatorResult.Balance.IsNil() {
+					row = append(row, "")
+				} else {
+					row = append(row, fmt.Sprintf("%f", validatorResult.Balance))
+				}
+
+				for _, shardID := range shardIDs {
+					if balance, ok := validatorResult.ShardBalances[shardID]; ok && !balance.IsNil() {
+						row = append(row, fmt.Sprintf("%f", balance))
+					} else {
+						row = append(row, "")
+					}
+				}
 
-			if config.ValidatorArgs.Balances && !validatorResult.Balance.IsNil() {
-				row = append(row, fmt.Sprintf("%f", validatorResult.Balance))
+				if validatorResult.Error != nil {
+					row = append(row, validatorResult.Error.Error())
+				} else {
+					row = append(row, "")
+				}
 			}
 
 			rows = append(rows, row)
@@ -158,3 +336,46 @@ func exportToCSV(validatorResults []ValidatorResult) (string, error) {
 
 	return csvPath, nil
 }
+
+func exportToJSON(validatorResults []ValidatorResult) (string, error) {
+	fileName := fmt.Sprintf("validators/validators-%s-UTC.json", utils.FormattedTimeString(time.Now().UTC()))
+
+	exports := []validatorExport{}
+	for _, validatorResult := range validatorResults {
+		validator := validatorResult.Result.Validator
+
+		validatorExport := validatorExport{
+			Name:            validator.Name,
+			Address:         formatAddress(validator.Address),
+			HexAddress:      hexAddress(validator.Address),
+			Identity:        validator.Identity,
+			BLSKeys:         validator.BLSPublicKeys,
+			SelfDelegation:  utils.DecToFloat(selfDelegation(validator).Amount),
+			TotalDelegation: utils.DecToFloat(validatorResult.Result.TotalDelegation),
+			LifetimeRewards: utils.DecToFloat(validatorResult.Result.Lifetime.RewardAccumulated),
+			CommissionRate:  utils.DecToFloat(validator.Rate),
+			MaxRate:         utils.DecToFloat(validator.MaxRate),
+			MaxChangeRate:   utils.DecToFloat(validator.MaxChangeRate),
+			Uptime:          validatorResult.Uptime,
+			Status:          validatorResult.Status,
+		}
+
+		if config.ValidatorArgs.Balances && !validatorResult.Balance.IsNil() {
+			balance := utils.DecToFloat(validatorResult.Balance)
+			validatorExport.WalletBalance = &balance
+
+			validatorExport.ShardBalances = make(map[uint32]float64)
+			for shardID, shardBalance := range validatorResult.ShardBalances {
+				validatorExport.ShardBalances[shardID] = utils.DecToFloat(shardBalance)
+			}
+		}
+
+		if validatorResult.Error != nil {
+			validatorExport.Error = validatorResult.Error.Error()
+		}
+
+		exports = append(exports, validatorExport)
+	}
+
+	return export.ExportJSON(fileName, exports)
+}
@@ -0,0 +1,262 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/logger"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+	"github.com/wcharczuk/go-chart"
+)
+
+var (
+	year = 365.25 * 24 * time.Hour
+)
+
+// APREstimate - the estimated APR for a given validator based on the rewards accumulated between two points in time
+type APREstimate struct {
+	Name           string  `json:"name"`
+	Address        string  `json:"address"`
+	HexAddress     string  `json:"hex-address,omitempty"`
+	Elected        bool    `json:"elected"`
+	CommissionRate float64 `json:"commission-rate"`
+	AverageStake   float64 `json:"average-stake"`
+	RewardDelta    float64 `json:"reward-delta"`
+	GrossAPR       float64 `json:"gross-apr"`
+	NetAPR         float64 `json:"net-apr"`
+}
+
+// EstimateAPR - estimate per validator APR from the rewards accumulated between two snapshots or epochs
+func EstimateAPR() error {
+	fmt.Printf("Will estimate validator APR - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	from, to, err := aprSnapshots()
+	if err != nil {
+		return err
+	}
+
+	duration := to.Time.Sub(from.Time)
+	if duration <= 0 {
+		return fmt.Errorf("the --to snapshot/epoch (%s) has to be more recent than the --from snapshot/epoch (%s)", to.Time.Format(time.RFC3339), from.Time.Format(time.RFC3339))
+	}
+
+	fmt.Printf("Estimating APR based on rewards accumulated between %s (%s) and %s (%s) - a period of %s\n", from.ID, from.Time.Format(time.RFC3339), to.ID, to.Time.Format(time.RFC3339), duration)
+
+	estimates, skipped := estimateAPR(from, to, duration)
+	if skipped > 0 {
+		logger.WarningLog(fmt.Sprintf("Skipped %d validator(s) with missing total delegation or lifetime reward amounts", skipped))
+	}
+
+	if len(estimates) == 0 {
+		return fmt.Errorf("no validators with stake were found in both %s and %s", from.ID, to.ID)
+	}
+
+	for index, estimate := range estimates {
+		fmt.Printf("#%d %s (%s) - net APR: %.2f%%, gross APR: %.2f%%, commission: %.2f%%, rewards: %.2f ONE, average stake: %.2f ONE\n", index+1, estimate.Name, estimate.Address, estimate.NetAPR, estimate.GrossAPR, estimate.CommissionRate*100, estimate.RewardDelta, estimate.AverageStake)
+	}
+
+	if err = chartAPR(estimates); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportAPRToCSV(estimates)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported APR estimates to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/apr-%s-UTC.json", utils.FormattedTimeString(time.Now().UTC())), estimates)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported APR estimates to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// aprSnapshots - use the validator state at the end of the supplied epochs, or fall back to stored snapshots
+func aprSnapshots() (from Snapshot, to Snapshot, err error) {
+	if config.ValidatorArgs.APR.FromEpoch > 0 || config.ValidatorArgs.APR.ToEpoch > 0 {
+		if config.ValidatorArgs.APR.FromEpoch == 0 || config.ValidatorArgs.APR.ToEpoch == 0 {
+			return from, to, fmt.Errorf("both --from-epoch and --to-epoch have to be supplied")
+		}
+
+		if from, err = snapshotAtEpoch(config.ValidatorArgs.APR.FromEpoch); err != nil {
+			return from, to, err
+		}
+
+		to, err = snapshotAtEpoch(config.ValidatorArgs.APR.ToEpoch)
+
+		return from, to, err
+	}
+
+	if from, err = LoadSnapshot(config.ValidatorArgs.APR.From); err != nil {
+		return from, to, err
+	}
+
+	to, err = LoadSnapshot(config.ValidatorArgs.APR.To)
+
+	return from, to, err
+}
+
+// snapshotAtEpoch - build an in-memory snapshot of all validators as of the last block of a given epoch
+func snapshotAtEpoch(epoch uint64) (Snapshot, error) {
+	snapshot := Snapshot{ID: fmt.Sprintf("epoch-%d", epoch), Network: config.Configuration.Network.Name}
+	node := config.Configuration.Network.API.NodeAddress(0)
+
+	blockNumber, err := rpc.EpochLastBlock(epoch, node)
+	if err != nil {
+		return snapshot, err
+	}
+
+	block, err := sdkRPC.GetBlockByNumber(blockNumber, false, node)
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.Time = block.Timestamp
+
+	fmt.Printf("Looking up validators as of the last block (#%d) of epoch %d\n", blockNumber, epoch)
+
+	validatorResults, err := sdkValidator.AllInformationForBlock(node, int(blockNumber), true)
+	if err != nil {
+		return snapshot, err
+	}
+
+	skipped := 0
+	for _, validatorResult := range validatorResults {
+		if uninitialized(validatorResult) {
+			skipped++
+			continue
+		}
+		snapshot.Validators = append(snapshot.Validators, SnapshotValidator{Result: validatorResult})
+	}
+
+	if skipped > 0 {
+		logger.WarningLog(fmt.Sprintf("Skipped %d validator(s) in epoch %d whose total delegation or lifetime rewards couldn't be decoded", skipped, epoch))
+	}
+
+	return snapshot, nil
+}
+
+// estimateAPR - annualize the rewards accumulated over the period relative to the average stake, net of the average of the commission rates in both snapshots
+// Commission rate changes within the period aren't tracked, validators with missing amounts in either snapshot are skipped and counted
+func estimateAPR(from Snapshot, to Snapshot, duration time.Duration) (estimates []APREstimate, skipped int) {
+	previous := make(map[string]sdkValidator.RPCValidatorResult)
+	for _, snapshotValidator := range from.Validators {
+		previous[snapshotValidator.Result.Validator.Address] = snapshotValidator.Result
+	}
+
+	annualizationFactor := float64(year) / float64(duration)
+
+	for _, snapshotValidator := range to.Validators {
+		current := snapshotValidator.Result
+		previousResult, exists := previous[current.Validator.Address]
+		if !exists {
+			continue
+		}
+
+		if uninitialized(current) || uninitialized(previousResult) {
+			skipped++
+			continue
+		}
+
+		averageStake := (utils.DecToFloat(previousResult.TotalDelegation) + utils.DecToFloat(current.TotalDelegation)) / 2
+		if averageStake <= 0 {
+			continue
+		}
+
+		estimate := APREstimate{
+			Name:           current.Validator.Name,
+			Address:        formatAddress(current.Validator.Address),
+			HexAddress:     hexAddress(current.Validator.Address),
+			Elected:        current.CurrentlyInCommittee,
+			CommissionRate: (utils.DecToFloat(previousResult.Validator.Rate) + utils.DecToFloat(current.Validator.Rate)) / 2,
+			AverageStake:   averageStake,
+			RewardDelta:    utils.DecToFloat(current.Lifetime.RewardAccumulated) - utils.DecToFloat(previousResult.Lifetime.RewardAccumulated),
+		}
+
+		estimate.GrossAPR = estimate.RewardDelta / averageStake * annualizationFactor * 100
+		estimate.NetAPR = estimate.GrossAPR * (1 - estimate.CommissionRate)
+
+		estimates = append(estimates, estimate)
+	}
+
+	sort.SliceStable(estimates, func(i, j int) bool {
+		return estimates[i].NetAPR > estimates[j].NetAPR
+	})
+
+	return estimates, skipped
+}
+
+func chartAPR(estimates []APREstimate) error {
+	limit := config.ValidatorArgs.APR.Limit
+	if limit <= 0 || limit > len(estimates) {
+		limit = len(estimates)
+	}
+
+	bars := []chart.Value{}
+	for _, estimate := range estimates[:limit] {
+		bars = append(bars, chart.Value{
+			Label: validatorLabel(estimate.Name, estimate.Address),
+			Value: estimate.NetAPR,
+		})
+	}
+
+	fileName := fmt.Sprintf("validators/%s-apr.png", strings.ToLower(config.Configuration.Network.Name))
+
+	return charts.GenerateBarChart(fileName, "Open Staking Validator Estimated APR - Net of Commission", "APR", func(v interface{}) string {
+		return formatPercentage(v.(float64))
+	}, bars)
+}
+
+func exportAPRToCSV(estimates []APREstimate) (string, error) {
+	fileName := fmt.Sprintf("validators/apr-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	headers := []string{"Name"}
+	headers = append(headers, addressHeaders("Address")...)
+	headers = append(headers,
+		"Elected",
+		"Commission Rate",
+		"Average Stake",
+		"Reward Delta",
+		"Gross APR (%)",
+		"Net APR (%)",
+	)
+
+	rows := [][]string{headers}
+
+	for _, estimate := range estimates {
+		row := []string{estimate.Name}
+		row = append(row, addressColumns(estimate.Address, estimate.HexAddress)...)
+		row = append(row,
+			fmt.Sprintf("%t", estimate.Elected),
+			fmt.Sprintf("%f", estimate.CommissionRate),
+			fmt.Sprintf("%f", estimate.AverageStake),
+			fmt.Sprintf("%f", estimate.RewardDelta),
+			fmt.Sprintf("%.4f", estimate.GrossAPR),
+			fmt.Sprintf("%.4f", estimate.NetAPR),
+		)
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
@@ -0,0 +1,321 @@
+package validators
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/utils"
+	"github.com/wcharczuk/go-chart"
+)
+
+// Commission - the commission settings for a given validator
+type Commission struct {
+	Name          string  `json:"name"`
+	Address       string  `json:"address"`
+	Rate          float64 `json:"commission-rate"`
+	MaxRate       float64 `json:"max-rate"`
+	MaxChangeRate float64 `json:"max-change-rate"`
+}
+
+// CommissionChange - a commission rate change for a given validator between a previous snapshot and now
+type CommissionChange struct {
+	Name         string  `json:"name"`
+	Address      string  `json:"address"`
+	HexAddress   string  `json:"hex-address,omitempty"`
+	PreviousRate float64 `json:"previous-rate"`
+	CurrentRate  float64 `json:"current-rate"`
+}
+
+// Commissions - graph the commission rate distribution of the elected validators and optionally diff them against a previous snapshot
+func Commissions() error {
+	fmt.Printf("Will analyze validator commissions - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	validatorResults, err := Filtered()
+	if err != nil {
+		return err
+	}
+
+	commissions := []Commission{}
+	electedCommissions := []Commission{}
+	for _, validatorResult := range validatorResults {
+		validator := validatorResult.Validator
+		commission := Commission{
+			Name:          validator.Name,
+			Address:       validator.Address,
+			Rate:          utils.DecToFloat(validator.Rate),
+			MaxRate:       utils.DecToFloat(validator.MaxRate),
+			MaxChangeRate: utils.DecToFloat(validator.MaxChangeRate),
+		}
+
+		commissions = append(commissions, commission)
+		if validatorResult.CurrentlyInCommittee {
+			electedCommissions = append(electedCommissions, commission)
+		}
+	}
+
+	fmt.Printf("Found a total of %d elected validators out of %d matching validators\n", len(electedCommissions), len(commissions))
+
+	if len(electedCommissions) > 0 {
+		if err = chartCommissions(fmt.Sprintf("validators/%s-commissions.png", strings.ToLower(config.Configuration.Network.Name)), electedCommissions); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("No elected validators found - skipping the commission distribution chart")
+	}
+
+	if config.ValidatorArgs.Commissions.Since != "" {
+		if err = diffCommissions(commissions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chartCommissions - chart how many validators fall into each commission rate bucket, buckets outside the range of actual rates are left out
+func chartCommissions(fileName string, commissions []Commission) error {
+	bucketSize := config.ValidatorArgs.Commissions.BucketSize
+	if bucketSize <= 0 {
+		bucketSize = 5.0
+	}
+
+	rates := []float64{}
+	for _, commission := range commissions {
+		rates = append(rates, commission.Rate*100)
+	}
+
+	min, max := utils.MinMax(rates)
+	fmt.Printf("Commission rates of elected validators - min: %.2f%%, max: %.2f%%, mean: %.2f%%, median: %.2f%%\n", min, max, utils.Mean(rates), utils.Percentile(rates, 50))
+
+	bucketCount := int(math.Ceil(100.0 / bucketSize))
+	counts := make([]int, bucketCount)
+	for _, rate := range rates {
+		index := int(rate / bucketSize)
+		if index >= bucketCount {
+			index = bucketCount - 1
+		}
+		counts[index]++
+	}
+
+	// Only chart the range of buckets actually containing validators
+	first, last := int(min/bucketSize), int(max/bucketSize)
+	if first >= bucketCount {
+		first = bucketCount - 1
+	}
+	if last >= bucketCount {
+		last = bucketCount - 1
+	}
+
+	bars := []chart.Value{}
+	for index := first; index <= last; index++ {
+		lower := float64(index) * bucketSize
+		bars = append(bars, chart.Value{
+			Label: fmt.Sprintf("%.0f-%.0f%%", lower, math.Min(lower+bucketSize, 100)),
+			Value: float64(counts[index]),
+		})
+	}
+
+	return charts.GenerateBarChart(fileName, "Open Staking Validator Commission Rates - Elected Validators", "Validators", func(v interface{}) string {
+		return fmt.Sprintf("%d", int(math.RoundToEven(v.(float64))))
+	}, bars)
+}
+
+// diffCommissions - report validators that changed their commission rate since a previous snapshot
+func diffCommissions(commissions []Commission) error {
+	previousCommissions, err := loadCommissions(config.ValidatorArgs.Commissions.Since)
+	if err != nil {
+		return err
+	}
+
+	changes := []CommissionChange{}
+	for _, commission := range commissions {
+		previous, exists := previousCommissions[commission.Address]
+		if !exists || previous.Rate == commission.Rate {
+			continue
+		}
+
+		changes = append(changes, CommissionChange{
+			Name:         commission.Name,
+			Address:      formatAddress(commission.Address),
+			HexAddress:   hexAddress(commission.Address),
+			PreviousRate: previous.Rate,
+			CurrentRate:  commission.Rate,
+		})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return math.Abs(changes[i].CurrentRate-changes[i].PreviousRate) > math.Abs(changes[j].CurrentRate-changes[j].PreviousRate)
+	})
+
+	fmt.Printf("Found %d validator(s) that changed their commission rate since %s\n", len(changes), config.ValidatorArgs.Commissions.Since)
+	for _, change := range changes {
+		direction := "increased"
+		if change.CurrentRate < change.PreviousRate {
+			direction = "decreased"
+		}
+		fmt.Printf("Validator %s (%s) %s its commission rate from %.2f%% to %.2f%%\n", change.Name, change.Address, direction, change.PreviousRate*100, change.CurrentRate*100)
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportCommissionChangesToCSV(changes)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported commission changes to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/commission-changes-%s-UTC.json", utils.FormattedTimeString(time.Now().UTC())), changes)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported commission changes to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// loadCommissions - load commission rates from a stored snapshot or a previous `validators analyze` csv or json export
+func loadCommissions(path string) (map[string]Commission, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".csv" {
+		return loadCommissionsFromCSV(path)
+	}
+
+	if snapshot, err := LoadSnapshot(path); err == nil && len(snapshot.Validators) > 0 {
+		return loadCommissionsFromSnapshot(snapshot), nil
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		return loadCommissionsFromJSON(path)
+	}
+
+	return nil, fmt.Errorf("unsupported snapshot %s - supply a snapshot id or a csv or json export from `validators analyze`", path)
+}
+
+func loadCommissionsFromSnapshot(snapshot Snapshot) map[string]Commission {
+	commissions := make(map[string]Commission)
+	for _, snapshotValidator := range snapshot.Validators {
+		validator := snapshotValidator.Result.Validator
+		commissions[validator.Address] = Commission{
+			Name:          validator.Name,
+			Address:       validator.Address,
+			Rate:          utils.DecToFloat(validator.Rate),
+			MaxRate:       utils.DecToFloat(validator.MaxRate),
+			MaxChangeRate: utils.DecToFloat(validator.MaxChangeRate),
+		}
+	}
+
+	return commissions
+}
+
+func loadCommissionsFromJSON(path string) (map[string]Commission, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	exports := []Commission{}
+	if err = json.Unmarshal(bytes, &exports); err != nil {
+		return nil, fmt.Errorf("failed to parse %s - error: %s", path, err.Error())
+	}
+
+	commissions := make(map[string]Commission)
+	for _, commission := range exports {
+		commission.Address = parseExportedAddress(commission.Address)
+		commissions[commission.Address] = commission
+	}
+
+	return commissions, nil
+}
+
+func loadCommissionsFromCSV(path string) (map[string]Commission, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	columns := make(map[string]int)
+	for index, header := range records[0] {
+		columns[header] = index
+	}
+
+	addressColumn, hasAddress := columns["Address"]
+	rateColumn, hasRate := columns["Commission Rate"]
+	if !hasAddress || !hasRate {
+		return nil, fmt.Errorf("%s doesn't contain any commission rates - it has to be an export from `validators analyze`", path)
+	}
+
+	commissions := make(map[string]Commission)
+	for _, record := range records[1:] {
+		rate, err := strconv.ParseFloat(record[rateColumn], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commission rate %q in %s", record[rateColumn], path)
+		}
+
+		commission := Commission{Address: parseExportedAddress(record[addressColumn]), Rate: rate}
+		if nameColumn, exists := columns["Name"]; exists {
+			commission.Name = record[nameColumn]
+		}
+
+		commissions[commission.Address] = commission
+	}
+
+	return commissions, nil
+}
+
+func exportCommissionChangesToCSV(changes []CommissionChange) (string, error) {
+	fileName := fmt.Sprintf("validators/commission-changes-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	headers := []string{"Name"}
+	headers = append(headers, addressHeaders("Address")...)
+	headers = append(headers, "Previous Commission Rate", "Current Commission Rate")
+
+	rows := [][]string{headers}
+
+	for _, change := range changes {
+		row := []string{change.Name}
+		row = append(row, addressColumns(change.Address, change.HexAddress)...)
+		row = append(row, fmt.Sprintf("%f", change.PreviousRate), fmt.Sprintf("%f", change.CurrentRate))
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
+
+// parseExportedAddress - exports can use any address format, convert them back to bech32 so they can be matched against the current validators
+func parseExportedAddress(address string) string {
+	if parsed, err := utils.ToBech32Address(strings.TrimSpace(address)); err == nil {
+		return parsed
+	}
+
+	return address
+}
@@ -6,23 +6,49 @@ import (
 	"strings"
 
 	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkDelegation "github.com/harmony-one/go-lib/staking/delegation"
 	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
 )
 
 // All - return all validators
 func All() (validatorResults []sdkValidator.RPCValidatorResult, err error) {
+	validatorInformation, err := allInformation()
+	if err != nil {
+		return validatorResults, err
+	}
+
+	return toValidatorResults(validatorInformation), nil
+}
+
+// allInformation - return all validators including the fields go-lib doesn't decode (elected keys and booted statuses)
+func allInformation() (validatorInformation []rpc.ValidatorInformation, err error) {
 	fmt.Printf("Looking up validators - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
 
-	validatorResults, err = sdkValidator.AllInformation(config.Configuration.Network.API.NodeAddress(0), true)
+	validatorInformation, err = retrieveAllPages(config.Configuration.Network.API.NodeAddress(0))
 	if err != nil {
-		return validatorResults, err
+		return validatorInformation, err
 	}
 
-	sort.Slice(validatorResults, func(i, j int) bool {
-		return validatorResults[i].Lifetime.RewardAccumulated.GT(validatorResults[j].Lifetime.RewardAccumulated)
+	// Validators with identical rewards (e.g. never elected validators) are sorted by address to keep the order deterministic
+	sort.SliceStable(validatorInformation, func(i, j int) bool {
+		if validatorInformation[i].Lifetime.RewardAccumulated.Equal(validatorInformation[j].Lifetime.RewardAccumulated) {
+			return validatorInformation[i].Validator.Address < validatorInformation[j].Validator.Address
+		}
+		return validatorInformation[i].Lifetime.RewardAccumulated.GT(validatorInformation[j].Lifetime.RewardAccumulated)
 	})
 
-	return validatorResults, nil
+	return validatorInformation, nil
+}
+
+func toValidatorResults(validatorInformation []rpc.ValidatorInformation) []sdkValidator.RPCValidatorResult {
+	validatorResults := []sdkValidator.RPCValidatorResult{}
+	for _, information := range validatorInformation {
+		validatorResults = append(validatorResults, information.RPCValidatorResult)
+	}
+
+	return validatorResults
 }
 
 // Elected - return all elected validators
@@ -37,41 +63,46 @@ func Elected() (validatorResults []sdkValidator.RPCValidatorResult, err error) {
 	return validatorResults, nil
 }
 
-// AcceptableBLS - return all validators with the correct BLS key limit (1)
-func AcceptableBLS() (validatorResults []sdkValidator.RPCValidatorResult, err error) {
-	validatorResults, err = All()
+// Filtered - return all validators filtered by certain criteria
+func Filtered() (validatorResults []sdkValidator.RPCValidatorResult, err error) {
+	validatorInformation, err := filteredInformation()
 	if err != nil {
 		return validatorResults, err
 	}
 
-	validatorResults = applyAllowedBLSFilter(validatorResults)
-
-	return validatorResults, nil
+	return toValidatorResults(validatorInformation), nil
 }
 
-// Filtered - return all validators filtered by certain criteria
-func Filtered() (validatorResults []sdkValidator.RPCValidatorResult, err error) {
-	validatorResults, err = All()
+// filteredInformation - return all validators filtered by certain criteria, including the fields go-lib doesn't decode
+func filteredInformation() (validatorInformation []rpc.ValidatorInformation, err error) {
+	// Compile the filter before looking up validators so that invalid expressions fail fast
+	filter, err := CompileFilter()
 	if err != nil {
-		return validatorResults, err
+		return validatorInformation, err
 	}
 
-	if config.ValidatorArgs.Elected {
-		validatorResults = applyElectedFilter(validatorResults)
+	allValidatorInformation, err := allInformation()
+	if err != nil {
+		return validatorInformation, err
 	}
 
-	if applyFilters() {
-		filteredValidatorResults := []sdkValidator.RPCValidatorResult{}
-		for _, validatorResult := range validatorResults {
-			if matchesFilter(validatorResult) {
-				filteredValidatorResults = append(filteredValidatorResults, validatorResult)
-			}
+	validatorInformation = []rpc.ValidatorInformation{}
+	for _, information := range allValidatorInformation {
+		if matchesFilters(information.RPCValidatorResult, filter) {
+			validatorInformation = append(validatorInformation, information)
 		}
+	}
 
-		validatorResults = filteredValidatorResults
+	return validatorInformation, nil
+}
+
+// matchesFilters - check if a validator matches the --elected flag and the compiled filter expression (nil matches everything)
+func matchesFilters(validatorResult sdkValidator.RPCValidatorResult, filter FilterExpression) bool {
+	if config.ValidatorArgs.Elected && !validatorResult.CurrentlyInCommittee {
+		return false
 	}
 
-	return validatorResults, nil
+	return filter == nil || filter.Matches(validatorResult)
 }
 
 func applyElectedFilter(validatorResults []sdkValidator.RPCValidatorResult) []sdkValidator.RPCValidatorResult {
@@ -86,11 +117,11 @@ func applyElectedFilter(validatorResults []sdkValidator.RPCValidatorResult) []sd
 	return electedValidators
 }
 
-func applyAllowedBLSFilter(validatorResults []sdkValidator.RPCValidatorResult) []sdkValidator.RPCValidatorResult {
+func applyBLSKeyCountFilter(validatorResults []sdkValidator.RPCValidatorResult, keyCount int) []sdkValidator.RPCValidatorResult {
 	allowedBLSValidators := []sdkValidator.RPCValidatorResult{}
 
 	for _, validatorResult := range validatorResults {
-		if len(validatorResult.Validator.BLSPublicKeys) == 1 {
+		if len(validatorResult.Validator.BLSPublicKeys) == keyCount {
 			allowedBLSValidators = append(allowedBLSValidators, validatorResult)
 		}
 	}
@@ -98,32 +129,69 @@ func applyAllowedBLSFilter(validatorResults []sdkValidator.RPCValidatorResult) [
 	return allowedBLSValidators
 }
 
-func applyFilters() bool {
-	return config.ValidatorArgs.Filter.Field != "" && config.ValidatorArgs.Filter.Value != "" && config.ValidatorArgs.Filter.Mode != ""
+// uninitialized - go-lib silently ignores conversion errors of the raw stake and reward amounts, which leaves them nil (and thus 0 when converted)
+func uninitialized(validatorResult sdkValidator.RPCValidatorResult) bool {
+	return validatorResult.TotalDelegation.IsNil() || validatorResult.Lifetime.RewardAccumulated.IsNil()
+}
+
+// selfDelegation - return the validator's delegation to itself
+func selfDelegation(validator sdkValidator.RPCValidator) (selfDelegation sdkDelegation.DelegationInfo) {
+	for _, delegation := range validator.Delegations {
+		if delegation.DelegatorAddress == validator.Address {
+			return delegation
+		}
+	}
+
+	return selfDelegation
+}
+
+// formatAddress - format an address using the configured address format (one, hex or both)
+func formatAddress(address string) string {
+	return utils.FormatAddress(address, config.Configuration.Export.AddressFormat)
+}
+
+// exportBothAddresses - whether exports should contain both the bech32 and the hex address
+func exportBothAddresses() bool {
+	return strings.ToLower(config.Configuration.Export.AddressFormat) == "both"
 }
 
-func matchesFilter(validatorResult sdkValidator.RPCValidatorResult) bool {
-	currentValue := ""
+// hexAddress - the hex address exported next to the bech32 address, empty unless both address formats are exported
+func hexAddress(address string) string {
+	if !exportBothAddresses() {
+		return ""
+	}
 
-	switch strings.ToLower(config.ValidatorArgs.Filter.Field) {
-	case "website":
-		currentValue = validatorResult.Validator.Website
-	case "identity":
-		currentValue = validatorResult.Validator.Identity
-	default:
-		currentValue = ""
+	hexAddress, err := utils.ToHexAddress(address)
+	if err != nil {
+		return ""
 	}
 
-	currentValue = strings.ToLower(currentValue)
-	expectedValue := strings.ToLower(config.ValidatorArgs.Filter.Value)
+	return hexAddress
+}
+
+// addressHeaders - the csv header(s) for an address column, e.g. Address and Hex Address when both address formats are exported
+func addressHeaders(header string) []string {
+	if !exportBothAddresses() {
+		return []string{header}
+	}
 
-	if currentValue != "" {
-		if config.ValidatorArgs.Filter.Mode == "equals" {
-			return currentValue == expectedValue
-		} else if config.ValidatorArgs.Filter.Mode == "contains" {
-			return strings.Contains(currentValue, expectedValue)
-		}
+	return []string{header, strings.Replace(header, "Address", "Hex Address", 1)}
+}
+
+// addressColumns - the csv column(s) for an address, matching the headers returned by addressHeaders
+func addressColumns(address string, hexAddress string) []string {
+	if !exportBothAddresses() {
+		return []string{address}
+	}
+
+	return []string{address, hexAddress}
+}
+
+// validatorLabel - chart label for a validator, falling back to its (already formatted) address for validators without a name
+func validatorLabel(name string, address string) string {
+	if strings.TrimSpace(name) == "" {
+		return formatForLabel(address)
 	}
 
-	return true
+	return formatForLabel(name)
 }
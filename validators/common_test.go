@@ -0,0 +1,38 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SebastianJ/harmony-stats/config"
+)
+
+func TestAddressColumns(t *testing.T) {
+	defer func(format string) { config.Configuration.Export.AddressFormat = format }(config.Configuration.Export.AddressFormat)
+
+	address := "one1pdv9lrdwl0rg5vglh4xtyrv3wjk3wsqket7zxy"
+
+	config.Configuration.Export.AddressFormat = "one"
+	if headers := addressHeaders("Delegator Address"); !reflect.DeepEqual(headers, []string{"Delegator Address"}) {
+		t.Errorf("addressHeaders(%q) = %v, expected a single column", "Delegator Address", headers)
+	}
+	if hex := hexAddress(address); hex != "" {
+		t.Errorf("hexAddress(%q) = %q, expected an empty hex address unless both formats are exported", address, hex)
+	}
+	if columns := addressColumns(formatAddress(address), hexAddress(address)); !reflect.DeepEqual(columns, []string{address}) {
+		t.Errorf("addressColumns = %v, expected [%s]", columns, address)
+	}
+
+	config.Configuration.Export.AddressFormat = "both"
+	if headers := addressHeaders("Delegator Address"); !reflect.DeepEqual(headers, []string{"Delegator Address", "Delegator Hex Address"}) {
+		t.Errorf("addressHeaders(%q) = %v, expected separate bech32 and hex columns", "Delegator Address", headers)
+	}
+
+	hex := hexAddress(address)
+	if len(hex) != 42 || hex[:2] != "0x" {
+		t.Errorf("hexAddress(%q) = %q, expected a hex address", address, hex)
+	}
+	if columns := addressColumns(formatAddress(address), hex); !reflect.DeepEqual(columns, []string{address, hex}) {
+		t.Errorf("addressColumns = %v, expected [%s %s]", columns, address, hex)
+	}
+}
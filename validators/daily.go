@@ -22,11 +22,18 @@ var (
 func Daily() error {
 	fmt.Printf("Will generate a graph over daily validators - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
 
-	validatorResults, err := All()
+	validatorResults, err := Filtered()
 	if err != nil {
 		return err
 	}
 
+	electedResults := []sdkValidator.RPCValidatorResult{}
+	for _, validatorResult := range validatorResults {
+		if validatorResult.CurrentlyInCommittee {
+			electedResults = append(electedResults, validatorResult)
+		}
+	}
+
 	blockNumbers := []uint64{}
 	blockNumberValidatorCountMapping := identifyValidatorCountPerBlock(validatorResults)
 	for el := blockNumberValidatorCountMapping.Front(); el != nil; el = el.Next() {
@@ -39,46 +46,66 @@ func Daily() error {
 	fmt.Printf("Retrieving block information for %d block(s)\n", len(blockNumbers))
 
 	blocks := retrieveBlocks(config.Configuration.Network.API.NodeAddress(0), blockNumbers)
-	totalCount := 0
-	xAxisData := []time.Time{}
-	yAxisData := []float64{}
 	validatorCountPerDate := identifyValidatorCountPerDate(blocks, blockNumberValidatorCountMapping)
+	electedCountPerDate := identifyValidatorCountPerDate(blocks, identifyValidatorCountPerBlock(electedResults))
 
-	for el := validatorCountPerDate.Front(); el != nil; el = el.Next() {
-		dateString := el.Key.(string)
-		validatorCount := el.Value.(int)
-
-		date, err := time.Parse(timeFormat, dateString)
-		if err != nil {
-			return err
-		}
+	xAxisData, yAxisData, err := fillMissingDates(validatorCountPerDate)
+	if err != nil {
+		return err
+	}
 
-		xAxisData = append(xAxisData, date)
-		yAxisData = append(yAxisData, float64(validatorCount))
+	electedData := alignDates(xAxisData, electedCountPerDate)
 
+	totalCount := 0
+	for index, date := range xAxisData {
+		validatorCount := int(yAxisData[index])
 		totalCount = totalCount + validatorCount
-		fmt.Printf("Date %s - number of created validators: %d\n", date, validatorCount)
+		fmt.Printf("Date %s - number of created validators: %d, total: %d\n", date.Format(timeFormat), validatorCount, totalCount)
 	}
 
 	fmt.Printf("Total number of created validators: %d\n", totalCount)
 
+	seriesTitle := "New Validators"
+	electedTitle := "New Validators (Currently Elected)"
+	if config.ValidatorArgs.Daily.Cumulative {
+		yAxisData = cumulative(yAxisData)
+		electedData = cumulative(electedData)
+		seriesTitle = "Total Validators"
+		electedTitle = "Total Validators (Currently Elected)"
+	}
+
+	details := []string{
+		fmt.Sprintf("Validators: %d total", totalCount),
+	}
+
 	fileName := fmt.Sprintf("validators/%s-daily.png", strings.ToLower(config.Configuration.Network.Name))
-	err = charts.GenerateTimeSeriesChart(
+
+	if config.ValidatorArgs.Daily.OverlayElected {
+		details = append(details, fmt.Sprintf("Currently elected: %d", len(electedResults)))
+
+		return charts.GenerateMultiTimeSeriesChart(
+			fileName,
+			"Date",
+			"Validators",
+			"",
+			xAxisData,
+			[]charts.Series{
+				{Title: seriesTitle, YValues: yAxisData},
+				{Title: electedTitle, YValues: electedData},
+			},
+			details,
+		)
+	}
+
+	return charts.GenerateTimeSeriesChart(
 		fileName,
-		"Validator Count",
+		seriesTitle,
 		"Date",
 		"",
 		xAxisData,
 		yAxisData,
-		[]string{
-			fmt.Sprintf("Validators: %d total", totalCount),
-		},
+		details,
 	)
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
 
 func identifyValidatorCountPerBlock(validatorResults []sdkValidator.RPCValidatorResult) *orderedmap.OrderedMap {
@@ -129,6 +156,55 @@ func identifyValidatorCountPerDate(blocks []sdkRPC.BlockInfo, blockNumberValidat
 	return dateCounts
 }
 
+// fillMissingDates - expand the date counts to every date between the first and last date, using a count of zero for dates without any created validators
+func fillMissingDates(dateCounts *orderedmap.OrderedMap) (dates []time.Time, counts []float64, err error) {
+	first, last := dateCounts.Front(), dateCounts.Back()
+	if first == nil {
+		return dates, counts, nil
+	}
+
+	startDate, err := time.Parse(timeFormat, first.Key.(string))
+	if err != nil {
+		return dates, counts, err
+	}
+
+	endDate, err := time.Parse(timeFormat, last.Key.(string))
+	if err != nil {
+		return dates, counts, err
+	}
+
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+
+	return dates, alignDates(dates, dateCounts), nil
+}
+
+// alignDates - look up the count for each of the given dates, defaulting to zero
+func alignDates(dates []time.Time, dateCounts *orderedmap.OrderedMap) []float64 {
+	counts := []float64{}
+	for _, date := range dates {
+		count := 0
+		if value, exists := dateCounts.Get(date.Format(timeFormat)); exists {
+			count = value.(int)
+		}
+		counts = append(counts, float64(count))
+	}
+
+	return counts
+}
+
+func cumulative(values []float64) []float64 {
+	totals := []float64{}
+	total := 0.0
+	for _, value := range values {
+		total += value
+		totals = append(totals, total)
+	}
+
+	return totals
+}
+
 func retrieveBlocks(node string, blockNumbers []uint64) (blockResults []sdkRPC.BlockInfo) {
 	blocksChannel := make(chan sdkRPC.BlockInfo, len(blockNumbers))
 	var waitGroup sync.WaitGroup
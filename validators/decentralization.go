@@ -0,0 +1,218 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/utils"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// StakeShare - the stake and share of the total stake for a given elected validator
+type StakeShare struct {
+	Rank            int     `json:"rank"`
+	Name            string  `json:"name"`
+	Address         string  `json:"address"`
+	HexAddress      string  `json:"hex-address,omitempty"`
+	Stake           float64 `json:"stake"`
+	Share           float64 `json:"share"`
+	CumulativeShare float64 `json:"cumulative-share"`
+}
+
+// DecentralizationReport - stake distribution and decentralization metrics for the elected validators
+type DecentralizationReport struct {
+	Time                  time.Time    `json:"time"`
+	StakeSource           string       `json:"stake-source"`
+	MissingEffectiveStake int          `json:"missing-effective-stake,omitempty"`
+	ValidatorCount        int          `json:"validator-count"`
+	TotalStake            float64      `json:"total-stake"`
+	NakamotoCoefficient33 int          `json:"nakamoto-coefficient-33"`
+	NakamotoCoefficient66 int          `json:"nakamoto-coefficient-66"`
+	Gini                  float64      `json:"gini"`
+	HHI                   float64      `json:"hhi"`
+	Shares                []StakeShare `json:"shares"`
+}
+
+// Decentralization - calculate stake distribution and decentralization metrics for the elected validators
+func Decentralization() error {
+	fmt.Printf("Will calculate decentralization metrics - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	validatorInformation, err := allInformation()
+	if err != nil {
+		return err
+	}
+
+	report := DecentralizationReport{Time: time.Now().UTC(), StakeSource: "effective"}
+
+	// Voting power is based on the effective (EPoS) stake of the current committee - validators whose committee metrics
+	// don't contain an effective stake fall back to their total delegation instead of being counted with a stake of zero
+	for _, information := range validatorInformation {
+		if !information.CurrentlyInCommittee {
+			continue
+		}
+
+		validatorResult := information.RPCValidatorResult
+		stake, found := information.EffectiveStake()
+		if !found {
+			fmt.Printf("Validator %s (%s) has no effective stake in the committee metrics, falling back to its total delegation\n", validatorResult.Validator.Name, formatAddress(validatorResult.Validator.Address))
+			report.MissingEffectiveStake++
+			stake = validatorResult.TotalDelegation
+		}
+
+		if stake.IsNil() {
+			stake = numeric.ZeroDec()
+		}
+
+		report.Shares = append(report.Shares, StakeShare{
+			Name:       validatorResult.Validator.Name,
+			Address:    formatAddress(validatorResult.Validator.Address),
+			HexAddress: hexAddress(validatorResult.Validator.Address),
+			Stake:      utils.DecToFloat(stake),
+		})
+	}
+
+	if len(report.Shares) == 0 {
+		return fmt.Errorf("no elected validators found")
+	}
+
+	if report.MissingEffectiveStake == len(report.Shares) {
+		report.StakeSource = "total-delegation"
+	} else if report.MissingEffectiveStake > 0 {
+		fmt.Printf("%d of %d elected validators are missing an effective stake and use their total delegation instead\n", report.MissingEffectiveStake, len(report.Shares))
+	}
+
+	calculateDecentralization(&report)
+
+	for _, share := range report.Shares {
+		fmt.Printf("#%d %s (%s) - stake: %.2f ONE, share: %.4f%%, cumulative share: %.4f%%\n", share.Rank, share.Name, share.Address, share.Stake, share.Share*100, share.CumulativeShare*100)
+	}
+
+	fmt.Printf("Elected validators: %d, total %s stake: %.2f ONE\n", report.ValidatorCount, report.StakeSource, report.TotalStake)
+	fmt.Printf("Nakamoto coefficient (>33%% of voting power): %d\n", report.NakamotoCoefficient33)
+	fmt.Printf("Nakamoto coefficient (>66%% of voting power): %d\n", report.NakamotoCoefficient66)
+	fmt.Printf("Gini coefficient: %.4f\n", report.Gini)
+	fmt.Printf("Herfindahl-Hirschman index: %.2f\n", report.HHI)
+
+	if err = chartCumulativeStake(fmt.Sprintf("validators/%s-decentralization-%s.png", strings.ToLower(config.Configuration.Network.Name), utils.FormattedTimeString(report.Time)), report); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportDecentralizationToCSV(report)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported decentralization data to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/decentralization-%s-UTC.json", utils.FormattedTimeString(report.Time)), report)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported decentralization data to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// calculateDecentralization - rank validators by stake and calculate shares, Nakamoto coefficients, Gini and HHI
+func calculateDecentralization(report *DecentralizationReport) {
+	sort.SliceStable(report.Shares, func(i, j int) bool {
+		return report.Shares[i].Stake > report.Shares[j].Stake
+	})
+
+	report.ValidatorCount = len(report.Shares)
+	report.TotalStake = 0.0
+	stakes := []float64{}
+	for _, share := range report.Shares {
+		report.TotalStake += share.Stake
+		stakes = append(stakes, share.Stake)
+	}
+
+	shares := []float64{}
+	cumulativeShare := 0.0
+	for index := range report.Shares {
+		share := &report.Shares[index]
+		share.Rank = index + 1
+		if report.TotalStake > 0 {
+			share.Share = share.Stake / report.TotalStake
+		}
+		cumulativeShare += share.Share
+		share.CumulativeShare = cumulativeShare
+		shares = append(shares, share.Share)
+
+		if report.NakamotoCoefficient33 == 0 && cumulativeShare > 1.0/3.0 {
+			report.NakamotoCoefficient33 = share.Rank
+		}
+
+		if report.NakamotoCoefficient66 == 0 && cumulativeShare > 2.0/3.0 {
+			report.NakamotoCoefficient66 = share.Rank
+		}
+	}
+
+	report.Gini = utils.Gini(stakes)
+	report.HHI = utils.HHI(shares)
+}
+
+// chartCumulativeStake - plot the cumulative share of the stake against the validators ranked by stake, annotated with the decentralization metrics
+func chartCumulativeStake(fileName string, report DecentralizationReport) error {
+	xValues := []float64{0}
+	yValues := []float64{0}
+	for _, share := range report.Shares {
+		xValues = append(xValues, float64(share.Rank))
+		yValues = append(yValues, share.CumulativeShare*100)
+	}
+
+	return charts.GenerateContinousChart(
+		fileName,
+		"Cumulative Stake",
+		"Validators (ranked by stake)",
+		"Cumulative Stake (%)",
+		xValues,
+		yValues,
+		[]string{
+			"Harmony Stake Distribution",
+			fmt.Sprintf("Network: %s", config.Configuration.Network.Name),
+			fmt.Sprintf("Elected validators: %d", report.ValidatorCount),
+			fmt.Sprintf("Stake source: %s", report.StakeSource),
+			fmt.Sprintf("Nakamoto coefficient (33%%/66%%): %d/%d", report.NakamotoCoefficient33, report.NakamotoCoefficient66),
+			fmt.Sprintf("Gini: %.4f, HHI: %.2f", report.Gini, report.HHI),
+		},
+	)
+}
+
+func exportDecentralizationToCSV(report DecentralizationReport) (string, error) {
+	fileName := fmt.Sprintf("validators/decentralization-%s-UTC.csv", utils.FormattedTimeString(report.Time))
+
+	headers := []string{"Rank", "Name"}
+	headers = append(headers, addressHeaders("Address")...)
+	headers = append(headers, "Stake", "Share (%)", "Cumulative Share (%)")
+
+	rows := [][]string{headers}
+
+	for _, share := range report.Shares {
+		row := []string{fmt.Sprintf("%d", share.Rank), share.Name}
+		row = append(row, addressColumns(share.Address, share.HexAddress)...)
+		row = append(row,
+			fmt.Sprintf("%f", share.Stake),
+			fmt.Sprintf("%.4f", share.Share*100),
+			fmt.Sprintf("%.4f", share.CumulativeShare*100),
+		)
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
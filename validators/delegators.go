@@ -0,0 +1,276 @@
+package validators
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/utils"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// DelegationRecord - a single delegation made to a validator
+type DelegationRecord struct {
+	ValidatorName       string               `json:"validator-name"`
+	ValidatorAddress    string               `json:"validator-address"`
+	ValidatorHexAddress string               `json:"validator-hex-address,omitempty"`
+	DelegatorAddress    string               `json:"delegator-address"`
+	DelegatorHexAddress string               `json:"delegator-hex-address,omitempty"`
+	Amount              float64              `json:"amount"`
+	Reward              float64              `json:"reward"`
+	Undelegations       []UndelegationRecord `json:"undelegations,omitempty"`
+}
+
+// UndelegationRecord - a pending undelegation belonging to a delegation
+type UndelegationRecord struct {
+	Amount float64 `json:"amount"`
+	Epoch  int     `json:"epoch"`
+}
+
+// DelegatorSummary - the total amount delegated by a given delegator across all validators
+type DelegatorSummary struct {
+	Address        string  `json:"address"`
+	HexAddress     string  `json:"hex-address,omitempty"`
+	ValidatorCount int     `json:"validator-count"`
+	Amount         float64 `json:"amount"`
+	Reward         float64 `json:"reward"`
+}
+
+// Delegators - analyze and export all delegations made to the matching validators
+func Delegators() error {
+	fmt.Printf("Will analyze delegations - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	validatorResults, err := Filtered()
+	if err != nil {
+		return err
+	}
+
+	records := []DelegationRecord{}
+	delegatorCounts := make(map[string]int)
+	for _, validatorResult := range validatorResults {
+		validator := validatorResult.Validator
+		for _, delegation := range validator.Delegations {
+			record := DelegationRecord{
+				ValidatorName:       validator.Name,
+				ValidatorAddress:    formatAddress(validator.Address),
+				ValidatorHexAddress: hexAddress(validator.Address),
+				DelegatorAddress:    formatAddress(delegation.DelegatorAddress),
+				DelegatorHexAddress: hexAddress(delegation.DelegatorAddress),
+				Amount:              utils.DecToFloat(delegation.Amount),
+				Reward:              utils.DecToFloat(delegation.Reward),
+			}
+
+			for _, undelegation := range delegation.Undelegations {
+				record.Undelegations = append(record.Undelegations, UndelegationRecord{
+					Amount: utils.DecToFloat(undelegation.Amount),
+					Epoch:  undelegation.Epoch,
+				})
+			}
+
+			if record.Amount > 0 {
+				delegatorCounts[validator.Address]++
+			}
+
+			records = append(records, record)
+		}
+	}
+
+	fmt.Printf("Found a total of %d delegations to %d validators\n", len(records), len(validatorResults))
+
+	for _, validatorResult := range validatorResults {
+		fmt.Printf("Validator %s (%s) - active delegators: %d\n", validatorResult.Validator.Name, validatorResult.Validator.Address, delegatorCounts[validatorResult.Validator.Address])
+	}
+
+	summaries := summarizeDelegators(records)
+	fmt.Printf("Found a total of %d unique delegators\n", len(summaries))
+
+	limit := config.ValidatorArgs.Delegators.Limit
+	if limit <= 0 || limit > len(summaries) {
+		limit = len(summaries)
+	}
+
+	for index, summary := range summaries[:limit] {
+		fmt.Printf("#%d delegator %s - delegated: %.2f ONE to %d validator(s), rewards: %.2f ONE\n", index+1, summary.Address, summary.Amount, summary.ValidatorCount, summary.Reward)
+	}
+
+	if err = chartDelegationSizes(records); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportDelegationsToCSV(records)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported delegation data to %s\n", csvPath)
+		}
+
+		csvPath, err = exportDelegatorsToCSV(summaries)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported delegator data to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/delegations-%s-UTC.json", utils.FormattedTimeString(time.Now().UTC())), records)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported delegation data to %s\n", jsonPath)
+		}
+
+		jsonPath, err = export.ExportJSON(fmt.Sprintf("validators/delegators-%s-UTC.json", utils.FormattedTimeString(time.Now().UTC())), summaries)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported delegator data to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// summarizeDelegators - aggregate delegations per delegator, sorted by the total delegated amount
+func summarizeDelegators(records []DelegationRecord) []DelegatorSummary {
+	summaryMapping := make(map[string]*DelegatorSummary)
+	for _, record := range records {
+		summary, exists := summaryMapping[record.DelegatorAddress]
+		if !exists {
+			summary = &DelegatorSummary{Address: record.DelegatorAddress, HexAddress: record.DelegatorHexAddress}
+			summaryMapping[record.DelegatorAddress] = summary
+		}
+
+		summary.Amount += record.Amount
+		summary.Reward += record.Reward
+		if record.Amount > 0 {
+			summary.ValidatorCount++
+		}
+	}
+
+	summaries := []DelegatorSummary{}
+	for _, summary := range summaryMapping {
+		summaries = append(summaries, *summary)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Amount == summaries[j].Amount {
+			return summaries[i].Address < summaries[j].Address
+		}
+		return summaries[i].Amount > summaries[j].Amount
+	})
+
+	return summaries
+}
+
+// chartDelegationSizes - delegation amounts span several orders of magnitude, so they're bucketed per power of ten
+func chartDelegationSizes(records []DelegationRecord) error {
+	buckets := make(map[int]int)
+	minExponent, maxExponent := math.MaxInt32, math.MinInt32
+
+	for _, record := range records {
+		if record.Amount < 1 {
+			continue
+		}
+
+		exponent := int(math.Floor(math.Log10(record.Amount)))
+		buckets[exponent]++
+		if exponent < minExponent {
+			minExponent = exponent
+		}
+		if exponent > maxExponent {
+			maxExponent = exponent
+		}
+	}
+
+	if len(buckets) == 0 {
+		fmt.Println("No delegations of at least 1 ONE found - skipping the delegation size chart")
+		return nil
+	}
+
+	printer := message.NewPrinter(language.English)
+
+	bars := []chart.Value{}
+	for exponent := minExponent; exponent <= maxExponent; exponent++ {
+		lower := math.Pow(10, float64(exponent))
+		bars = append(bars, chart.Value{
+			Label: printer.Sprintf("%d - %d ONE", int64(lower), int64(lower*10)),
+			Value: float64(buckets[exponent]),
+		})
+	}
+
+	fileName := fmt.Sprintf("validators/%s-delegation-sizes.png", strings.ToLower(config.Configuration.Network.Name))
+
+	return charts.GenerateBarChart(fileName, "Open Staking Delegation Size Distribution", "Delegations", func(v interface{}) string {
+		return printer.Sprintf("%d", int(math.RoundToEven(v.(float64))))
+	}, bars)
+}
+
+func exportDelegationsToCSV(records []DelegationRecord) (string, error) {
+	fileName := fmt.Sprintf("validators/delegations-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	headers := []string{"Validator Name"}
+	headers = append(headers, addressHeaders("Validator Address")...)
+	headers = append(headers, addressHeaders("Delegator Address")...)
+	headers = append(headers, "Amount", "Reward", "Undelegations")
+
+	rows := [][]string{headers}
+
+	for _, record := range records {
+		undelegations := []string{}
+		for _, undelegation := range record.Undelegations {
+			undelegations = append(undelegations, fmt.Sprintf("%f ONE (epoch %d)", undelegation.Amount, undelegation.Epoch))
+		}
+
+		row := []string{record.ValidatorName}
+		row = append(row, addressColumns(record.ValidatorAddress, record.ValidatorHexAddress)...)
+		row = append(row, addressColumns(record.DelegatorAddress, record.DelegatorHexAddress)...)
+		row = append(row,
+			fmt.Sprintf("%f", record.Amount),
+			fmt.Sprintf("%f", record.Reward),
+			strings.Join(undelegations, "\n"),
+		)
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
+
+func exportDelegatorsToCSV(summaries []DelegatorSummary) (string, error) {
+	fileName := fmt.Sprintf("validators/delegators-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	headers := addressHeaders("Delegator Address")
+	headers = append(headers, "Validator Count", "Amount", "Reward")
+
+	rows := [][]string{headers}
+
+	for _, summary := range summaries {
+		row := addressColumns(summary.Address, summary.HexAddress)
+		row = append(row,
+			fmt.Sprintf("%d", summary.ValidatorCount),
+			fmt.Sprintf("%f", summary.Amount),
+			fmt.Sprintf("%f", summary.Reward),
+		)
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
@@ -0,0 +1,253 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+)
+
+// ValidatorDiff - the changes for a given validator between two snapshots
+type ValidatorDiff struct {
+	Name                 string   `json:"name"`
+	Address              string   `json:"address"`
+	HexAddress           string   `json:"hex-address,omitempty"`
+	Status               string   `json:"status"`
+	PreviouslyElected    bool     `json:"previously-elected"`
+	CurrentlyElected     bool     `json:"currently-elected"`
+	TotalDelegationDelta float64  `json:"total-delegation-delta"`
+	RewardDelta          float64  `json:"reward-delta"`
+	AddedBLSKeys         []string `json:"added-bls-keys,omitempty"`
+	RemovedBLSKeys       []string `json:"removed-bls-keys,omitempty"`
+}
+
+// SnapshotDiff - the differences between two validator snapshots
+type SnapshotDiff struct {
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	FromTime   time.Time       `json:"from-time"`
+	ToTime     time.Time       `json:"to-time"`
+	Validators []ValidatorDiff `json:"validators"`
+}
+
+// Diff - compare two validator snapshots
+func Diff() error {
+	from, err := LoadSnapshot(config.ValidatorArgs.Diff.From)
+	if err != nil {
+		return err
+	}
+
+	to, err := LoadSnapshot(config.ValidatorArgs.Diff.To)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Comparing validator snapshot %s (%s) to snapshot %s (%s)\n", from.ID, from.Time.Format(time.RFC3339), to.ID, to.Time.Format(time.RFC3339))
+
+	diff := diffSnapshots(from, to)
+
+	outputDiff(diff)
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportDiffToCSV(diff)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported the snapshot diff to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/diff-%s-%s.json", diff.From, diff.To), diff)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported the snapshot diff to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+func diffSnapshots(from Snapshot, to Snapshot) SnapshotDiff {
+	diff := SnapshotDiff{From: from.ID, To: to.ID, FromTime: from.Time, ToTime: to.Time}
+
+	previous := make(map[string]sdkValidator.RPCValidatorResult)
+	for _, snapshotValidator := range from.Validators {
+		previous[snapshotValidator.Result.Validator.Address] = snapshotValidator.Result
+	}
+
+	current := make(map[string]sdkValidator.RPCValidatorResult)
+	for _, snapshotValidator := range to.Validators {
+		current[snapshotValidator.Result.Validator.Address] = snapshotValidator.Result
+	}
+
+	for address, currentResult := range current {
+		previousResult, exists := previous[address]
+		if !exists {
+			diff.Validators = append(diff.Validators, ValidatorDiff{
+				Name:                 currentResult.Validator.Name,
+				Address:              formatAddress(address),
+				HexAddress:           hexAddress(address),
+				Status:               "new",
+				CurrentlyElected:     currentResult.CurrentlyInCommittee,
+				TotalDelegationDelta: utils.DecToFloat(currentResult.TotalDelegation),
+				RewardDelta:          utils.DecToFloat(currentResult.Lifetime.RewardAccumulated),
+				AddedBLSKeys:         currentResult.Validator.BLSPublicKeys,
+			})
+			continue
+		}
+
+		validatorDiff := ValidatorDiff{
+			Name:                 currentResult.Validator.Name,
+			Address:              formatAddress(address),
+			HexAddress:           hexAddress(address),
+			Status:               "unchanged",
+			PreviouslyElected:    previousResult.CurrentlyInCommittee,
+			CurrentlyElected:     currentResult.CurrentlyInCommittee,
+			TotalDelegationDelta: utils.DecToFloat(currentResult.TotalDelegation) - utils.DecToFloat(previousResult.TotalDelegation),
+			RewardDelta:          utils.DecToFloat(currentResult.Lifetime.RewardAccumulated) - utils.DecToFloat(previousResult.Lifetime.RewardAccumulated),
+		}
+
+		validatorDiff.AddedBLSKeys, validatorDiff.RemovedBLSKeys = diffKeys(previousResult.Validator.BLSPublicKeys, currentResult.Validator.BLSPublicKeys)
+
+		if validatorDiff.PreviouslyElected != validatorDiff.CurrentlyElected || validatorDiff.TotalDelegationDelta != 0 || validatorDiff.RewardDelta != 0 || len(validatorDiff.AddedBLSKeys) > 0 || len(validatorDiff.RemovedBLSKeys) > 0 {
+			validatorDiff.Status = "changed"
+		}
+
+		diff.Validators = append(diff.Validators, validatorDiff)
+	}
+
+	for address, previousResult := range previous {
+		if _, exists := current[address]; !exists {
+			diff.Validators = append(diff.Validators, ValidatorDiff{
+				Name:                 previousResult.Validator.Name,
+				Address:              formatAddress(address),
+				HexAddress:           hexAddress(address),
+				Status:               "removed",
+				PreviouslyElected:    previousResult.CurrentlyInCommittee,
+				TotalDelegationDelta: -utils.DecToFloat(previousResult.TotalDelegation),
+				RemovedBLSKeys:       previousResult.Validator.BLSPublicKeys,
+			})
+		}
+	}
+
+	sort.SliceStable(diff.Validators, func(i, j int) bool {
+		if diff.Validators[i].Status != diff.Validators[j].Status {
+			return diff.Validators[i].Status < diff.Validators[j].Status
+		}
+		return diff.Validators[i].Address < diff.Validators[j].Address
+	})
+
+	return diff
+}
+
+// diffKeys - identify the keys added to and removed from a set of keys
+func diffKeys(previous []string, current []string) (added []string, removed []string) {
+	previousKeys := make(map[string]bool)
+	for _, key := range previous {
+		previousKeys[key] = true
+	}
+
+	currentKeys := make(map[string]bool)
+	for _, key := range current {
+		currentKeys[key] = true
+		if !previousKeys[key] {
+			added = append(added, key)
+		}
+	}
+
+	for _, key := range previous {
+		if !currentKeys[key] {
+			removed = append(removed, key)
+		}
+	}
+
+	return added, removed
+}
+
+func outputDiff(diff SnapshotDiff) {
+	counts := make(map[string]int)
+	elected, unelected := 0, 0
+
+	for _, validatorDiff := range diff.Validators {
+		counts[validatorDiff.Status]++
+
+		switch validatorDiff.Status {
+		case "new":
+			fmt.Printf("New validator %s (%s) - elected: %t, bls keys: %d\n", validatorDiff.Name, validatorDiff.Address, validatorDiff.CurrentlyElected, len(validatorDiff.AddedBLSKeys))
+		case "removed":
+			fmt.Printf("Removed validator %s (%s)\n", validatorDiff.Name, validatorDiff.Address)
+		case "changed":
+			changes := []string{}
+			if !validatorDiff.PreviouslyElected && validatorDiff.CurrentlyElected {
+				changes = append(changes, "got elected")
+				elected++
+			} else if validatorDiff.PreviouslyElected && !validatorDiff.CurrentlyElected {
+				changes = append(changes, "lost election")
+				unelected++
+			}
+			if validatorDiff.TotalDelegationDelta != 0 {
+				changes = append(changes, fmt.Sprintf("total delegation %+.2f ONE", validatorDiff.TotalDelegationDelta))
+			}
+			if validatorDiff.RewardDelta != 0 {
+				changes = append(changes, fmt.Sprintf("rewards %+.2f ONE", validatorDiff.RewardDelta))
+			}
+			if len(validatorDiff.AddedBLSKeys) > 0 {
+				changes = append(changes, fmt.Sprintf("added bls keys: %s", strings.Join(validatorDiff.AddedBLSKeys, ", ")))
+			}
+			if len(validatorDiff.RemovedBLSKeys) > 0 {
+				changes = append(changes, fmt.Sprintf("removed bls keys: %s", strings.Join(validatorDiff.RemovedBLSKeys, ", ")))
+			}
+			fmt.Printf("Validator %s (%s) - %s\n", validatorDiff.Name, validatorDiff.Address, strings.Join(changes, ", "))
+		}
+	}
+
+	fmt.Printf("New validators: %d, removed validators: %d, changed validators: %d, unchanged validators: %d\n", counts["new"], counts["removed"], counts["changed"], counts["unchanged"])
+	fmt.Printf("Newly elected validators: %d, no longer elected validators: %d\n", elected, unelected)
+}
+
+func exportDiffToCSV(diff SnapshotDiff) (string, error) {
+	fileName := fmt.Sprintf("validators/diff-%s-%s.csv", diff.From, diff.To)
+
+	headers := []string{"Name"}
+	headers = append(headers, addressHeaders("Address")...)
+	headers = append(headers,
+		"Status",
+		"Previously Elected",
+		"Currently Elected",
+		"Total Delegation Delta",
+		"Reward Delta",
+		"Added BLS Keys",
+		"Removed BLS Keys",
+	)
+
+	rows := [][]string{headers}
+
+	for _, validatorDiff := range diff.Validators {
+		row := []string{validatorDiff.Name}
+		row = append(row, addressColumns(validatorDiff.Address, validatorDiff.HexAddress)...)
+		row = append(row,
+			validatorDiff.Status,
+			fmt.Sprintf("%t", validatorDiff.PreviouslyElected),
+			fmt.Sprintf("%t", validatorDiff.CurrentlyElected),
+			fmt.Sprintf("%f", validatorDiff.TotalDelegationDelta),
+			fmt.Sprintf("%f", validatorDiff.RewardDelta),
+			strings.Join(validatorDiff.AddedBLSKeys, "\n"),
+			strings.Join(validatorDiff.RemovedBLSKeys, "\n"),
+		)
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
@@ -0,0 +1,241 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/logger"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+)
+
+// ElectionResult - the outcome of the election for a given epoch
+type ElectionResult struct {
+	Epoch             uint64    `json:"epoch"`
+	ElectionBlock     uint64    `json:"election-block"`
+	Time              time.Time `json:"time"`
+	ElectedValidators int       `json:"elected-validators"`
+	ElectedBLSKeys    int       `json:"elected-bls-keys"`
+	MedianWinningBid  float64   `json:"median-winning-bid"`
+	LowestWinningBid  float64   `json:"lowest-winning-bid"`
+	MissingBids       int       `json:"missing-bids,omitempty"`
+}
+
+// Elections - generate a graph of the election results for a range of epochs
+func Elections() error {
+	fmt.Printf("Will generate a graph of the election history - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	fromEpoch, toEpoch := config.ValidatorArgs.Elections.FromEpoch, config.ValidatorArgs.Elections.ToEpoch
+	if fromEpoch == 0 || toEpoch == 0 {
+		return fmt.Errorf("both --from-epoch and --to-epoch have to be supplied and be greater than 0")
+	}
+
+	if toEpoch < fromEpoch {
+		return fmt.Errorf("--to-epoch (%d) has to be greater than or equal to --from-epoch (%d)", toEpoch, fromEpoch)
+	}
+
+	results := []ElectionResult{}
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		result, err := electionResultForEpoch(epoch)
+		if err != nil {
+			return err
+		}
+
+		if result.MissingBids > 0 {
+			logger.WarningLog(fmt.Sprintf("Epoch %d - left %d elected validator(s) whose total delegation couldn't be decoded out of the winning bids", result.Epoch, result.MissingBids))
+		}
+
+		fmt.Printf("Epoch %d - elected validators: %d, elected bls keys: %d, median winning bid: %.2f ONE, lowest winning bid: %.2f ONE\n", result.Epoch, result.ElectedValidators, result.ElectedBLSKeys, result.MedianWinningBid, result.LowestWinningBid)
+
+		results = append(results, result)
+	}
+
+	if err := chartElections(results); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportElectionsToCSV(results)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported the election history to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/elections-%d-%d.json", fromEpoch, toEpoch), results)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported the election history to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// electionResultForEpoch - the committee of an epoch is elected in the last block of the previous epoch
+// Winning bids are calculated as the total delegation at the time of the election divided by the number of slots the validator won
+func electionResultForEpoch(epoch uint64) (ElectionResult, error) {
+	result := ElectionResult{Epoch: epoch}
+	node := config.Configuration.Network.API.NodeAddress(0)
+
+	blockNumber, err := rpc.EpochLastBlock(epoch-1, node)
+	if err != nil {
+		return result, err
+	}
+	result.ElectionBlock = blockNumber
+
+	block, err := sdkRPC.GetBlockByNumber(blockNumber, false, node)
+	if err != nil {
+		return result, err
+	}
+	result.Time = block.Timestamp
+
+	fmt.Printf("Looking up the election for epoch %d held in block #%d\n", epoch, blockNumber)
+
+	slots, err := committeeSlots(epoch)
+	if err != nil {
+		return result, err
+	}
+
+	validatorResults, err := sdkValidator.AllInformationForBlock(node, int(blockNumber), true)
+	if err != nil {
+		return result, err
+	}
+
+	bids := []float64{}
+	for _, validatorResult := range validatorResults {
+		slotCount := slots[validatorResult.Validator.Address]
+		if slotCount == 0 {
+			continue
+		}
+
+		result.ElectedValidators++
+		result.ElectedBLSKeys += slotCount
+
+		// go-lib ignores conversion errors, a nil total delegation would otherwise be counted as a winning bid of 0
+		if validatorResult.TotalDelegation.IsNil() {
+			result.MissingBids++
+			continue
+		}
+
+		bids = append(bids, utils.DecToFloat(validatorResult.TotalDelegation)/float64(slotCount))
+	}
+
+	if len(bids) > 0 {
+		result.MedianWinningBid = utils.Percentile(bids, 50)
+		result.LowestWinningBid, _ = utils.MinMax(bids)
+	}
+
+	return result, nil
+}
+
+// committeeSlots - count the number of slots won per address across all shards for a given epoch
+func committeeSlots(epoch uint64) (map[string]int, error) {
+	shardIDs := []uint32{}
+	for shardID := range config.Configuration.Network.API.Shards {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sort.Slice(shardIDs, func(i, j int) bool {
+		return shardIDs[i] < shardIDs[j]
+	})
+
+	slots := make(map[string]int)
+	for _, shardID := range shardIDs {
+		committee, err := rpc.GetEpochCommittee(epoch, config.Configuration.Network.API.Shards[shardID].Node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up the committee for shard %d during epoch %d - error: %s", shardID, epoch, err.Error())
+		}
+
+		for _, member := range committee.Members {
+			slots[member.Address]++
+		}
+	}
+
+	return slots, nil
+}
+
+func chartElections(results []ElectionResult) error {
+	xAxisData := []time.Time{}
+	validatorData := []float64{}
+	keyData := []float64{}
+	medianData := []float64{}
+	lowestData := []float64{}
+
+	for _, result := range results {
+		xAxisData = append(xAxisData, result.Time)
+		validatorData = append(validatorData, float64(result.ElectedValidators))
+		keyData = append(keyData, float64(result.ElectedBLSKeys))
+		medianData = append(medianData, result.MedianWinningBid)
+		lowestData = append(lowestData, result.LowestWinningBid)
+	}
+
+	first, last := results[0], results[len(results)-1]
+	fileName := fmt.Sprintf("validators/%s-elections.png", strings.ToLower(config.Configuration.Network.Name))
+
+	return charts.GenerateMultiTimeSeriesChart(
+		fileName,
+		"Date",
+		"Elected",
+		"Winning Bid (ONE)",
+		xAxisData,
+		[]charts.Series{
+			{Title: "Elected Validators", YValues: validatorData},
+			{Title: "Elected BLS Keys", YValues: keyData},
+			{Title: "Median Winning Bid", YValues: medianData, Secondary: true},
+			{Title: "Lowest Winning Bid", YValues: lowestData, Secondary: true},
+		},
+		[]string{
+			"Open Staking Election History",
+			fmt.Sprintf("Network: %s", config.Configuration.Network.Name),
+			fmt.Sprintf("Epochs: %d - %d", first.Epoch, last.Epoch),
+			fmt.Sprintf("Elected validators: %d - %d", first.ElectedValidators, last.ElectedValidators),
+		},
+	)
+}
+
+func exportElectionsToCSV(results []ElectionResult) (string, error) {
+	fileName := fmt.Sprintf("validators/elections-%d-%d.csv", results[0].Epoch, results[len(results)-1].Epoch)
+
+	rows := [][]string{
+		{
+			"Epoch",
+			"Election Block",
+			"Time",
+			"Elected Validators",
+			"Elected BLS Keys",
+			"Median Winning Bid",
+			"Lowest Winning Bid",
+			"Missing Bids",
+		},
+	}
+
+	for _, result := range results {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", result.Epoch),
+			fmt.Sprintf("%d", result.ElectionBlock),
+			result.Time.UTC().Format(time.RFC3339),
+			fmt.Sprintf("%d", result.ElectedValidators),
+			fmt.Sprintf("%d", result.ElectedBLSKeys),
+			fmt.Sprintf("%f", result.MedianWinningBid),
+			fmt.Sprintf("%f", result.LowestWinningBid),
+			fmt.Sprintf("%d", result.MissingBids),
+		})
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
@@ -0,0 +1,580 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// Filter expressions are made up of comparisons combined using and/or/not and parentheses, e.g:
+//
+//	total_delegation > 10000000 and elected and bls_keys >= 2 and name ~ /foo/
+//
+// Supported operators: == (or =), !=, >, >=, <, <= and ~ / !~ (regex or case insensitive substring match).
+// Boolean fields can be used on their own (e.g. "elected" or "not elected").
+
+type fieldType int
+
+const (
+	stringField fieldType = iota
+	numberField
+	boolField
+)
+
+func (kind fieldType) String() string {
+	switch kind {
+	case stringField:
+		return "string"
+	case numberField:
+		return "number"
+	case boolField:
+		return "boolean"
+	default:
+		return "unknown"
+	}
+}
+
+type filterField struct {
+	kind  fieldType
+	value func(validatorResult sdkValidator.RPCValidatorResult) interface{}
+}
+
+var filterFields = map[string]filterField{
+	"address":          stringFilterField(func(r sdkValidator.RPCValidatorResult) string { return r.Validator.Address }),
+	"name":             stringFilterField(func(r sdkValidator.RPCValidatorResult) string { return r.Validator.Name }),
+	"identity":         stringFilterField(func(r sdkValidator.RPCValidatorResult) string { return r.Validator.Identity }),
+	"website":          stringFilterField(func(r sdkValidator.RPCValidatorResult) string { return r.Validator.Website }),
+	"security_contact": stringFilterField(func(r sdkValidator.RPCValidatorResult) string { return r.Validator.SecurityContact }),
+	"details":          stringFilterField(func(r sdkValidator.RPCValidatorResult) string { return r.Validator.Details }),
+	"bls_key": stringFilterField(func(r sdkValidator.RPCValidatorResult) string {
+		return strings.Join(r.Validator.BLSPublicKeys, ",")
+	}),
+	"eligibility_status": stringFilterField(func(r sdkValidator.RPCValidatorResult) string { return r.Validator.EligibilityStatus }),
+	"epos_status":        stringFilterField(func(r sdkValidator.RPCValidatorResult) string { return r.EposStatus }),
+
+	"bls_keys": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(len(r.Validator.BLSPublicKeys)))
+	}),
+	"delegations": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(len(r.Validator.Delegations)))
+	}),
+	"creation_height": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.Validator.CreationHeight))
+	}),
+	"update_height": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.Validator.UpdateHeight))
+	}),
+	"last_epoch_in_committee": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.Validator.LastEpochInCommittee))
+	}),
+	"max_total_delegation": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return r.Validator.MaxTotalDelegation }),
+	"min_self_delegation":  numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return r.Validator.MinSelfDelegation }),
+	"self_delegation":      numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return selfDelegation(r.Validator).Amount }),
+	"total_delegation":     numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return r.TotalDelegation }),
+	"rate":                 numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return r.Validator.Rate }),
+	"max_rate":             numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return r.Validator.MaxRate }),
+	"max_change_rate":      numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return r.Validator.MaxChangeRate }),
+	"blocks_signed": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.Validator.Availability.BlocksSigned))
+	}),
+	"blocks_to_sign": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.Validator.Availability.BlocksToSign))
+	}),
+	"current_epoch_signed": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.CurrentEpochPerformance.CurrentEpochSigned))
+	}),
+	"current_epoch_to_sign": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.CurrentEpochPerformance.CurrentEpochToSign))
+	}),
+	"current_epoch_signing_percentage": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return r.CurrentEpochPerformance.CurrentEpochSigningPercentage
+	}),
+	"lifetime_rewards": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return r.Lifetime.RewardAccumulated }),
+	"lifetime_signed": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.Lifetime.Blocks.Signed))
+	}),
+	"lifetime_to_sign": numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec {
+		return numeric.NewDec(int64(r.Lifetime.Blocks.ToSign))
+	}),
+	"apr":               numberFilterField(func(r sdkValidator.RPCValidatorResult) numeric.Dec { return r.Lifetime.APR }),
+	"elected":           boolFilterField(func(r sdkValidator.RPCValidatorResult) bool { return r.CurrentlyInCommittee }),
+	"currently_elected": boolFilterField(func(r sdkValidator.RPCValidatorResult) bool { return r.CurrentlyInCommittee }),
+}
+
+func stringFilterField(value func(sdkValidator.RPCValidatorResult) string) filterField {
+	return filterField{kind: stringField, value: func(r sdkValidator.RPCValidatorResult) interface{} { return value(r) }}
+}
+
+func numberFilterField(value func(sdkValidator.RPCValidatorResult) numeric.Dec) filterField {
+	return filterField{kind: numberField, value: func(r sdkValidator.RPCValidatorResult) interface{} {
+		dec := value(r)
+		if dec.IsNil() {
+			return numeric.ZeroDec()
+		}
+		return dec
+	}}
+}
+
+func boolFilterField(value func(sdkValidator.RPCValidatorResult) bool) filterField {
+	return filterField{kind: boolField, value: func(r sdkValidator.RPCValidatorResult) interface{} { return value(r) }}
+}
+
+// FilterFieldNames - return the names of all fields that can be used in filter expressions
+func FilterFieldNames() []string {
+	names := []string{}
+	for name := range filterFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// FilterExpression - a compiled validator filter expression
+type FilterExpression interface {
+	Matches(validatorResult sdkValidator.RPCValidatorResult) bool
+}
+
+type andExpression struct {
+	left  FilterExpression
+	right FilterExpression
+}
+
+func (e andExpression) Matches(validatorResult sdkValidator.RPCValidatorResult) bool {
+	return e.left.Matches(validatorResult) && e.right.Matches(validatorResult)
+}
+
+type orExpression struct {
+	left  FilterExpression
+	right FilterExpression
+}
+
+func (e orExpression) Matches(validatorResult sdkValidator.RPCValidatorResult) bool {
+	return e.left.Matches(validatorResult) || e.right.Matches(validatorResult)
+}
+
+type notExpression struct {
+	expression FilterExpression
+}
+
+func (e notExpression) Matches(validatorResult sdkValidator.RPCValidatorResult) bool {
+	return !e.expression.Matches(validatorResult)
+}
+
+type comparisonExpression struct {
+	field    filterField
+	operator string
+	value    interface{}
+}
+
+func (e comparisonExpression) Matches(validatorResult sdkValidator.RPCValidatorResult) bool {
+	current := e.field.value(validatorResult)
+
+	switch e.field.kind {
+	case boolField:
+		matches := current.(bool) == e.value.(bool)
+		if e.operator == "!=" {
+			return !matches
+		}
+		return matches
+
+	case numberField:
+		currentValue := current.(numeric.Dec)
+		expectedValue := e.value.(numeric.Dec)
+		switch e.operator {
+		case "==":
+			return currentValue.Equal(expectedValue)
+		case "!=":
+			return !currentValue.Equal(expectedValue)
+		case ">":
+			return currentValue.GT(expectedValue)
+		case ">=":
+			return currentValue.GTE(expectedValue)
+		case "<":
+			return currentValue.LT(expectedValue)
+		case "<=":
+			return currentValue.LTE(expectedValue)
+		}
+
+	case stringField:
+		currentValue := strings.ToLower(current.(string))
+		switch e.operator {
+		case "==":
+			return currentValue == e.value.(string)
+		case "!=":
+			return currentValue != e.value.(string)
+		case "~", "!~":
+			matches := false
+			if regex, ok := e.value.(*regexp.Regexp); ok {
+				matches = regex.MatchString(current.(string))
+			} else {
+				matches = strings.Contains(currentValue, e.value.(string))
+			}
+			if e.operator == "!~" {
+				return !matches
+			}
+			return matches
+		}
+	}
+
+	return false
+}
+
+// CompileFilter - compile the filter expression (and legacy --filter.field/--filter.value/--filter.mode flags) supplied via the command line
+// Returns nil if no filters have been supplied
+func CompileFilter() (FilterExpression, error) {
+	expressions := []string{}
+
+	if config.ValidatorArgs.Filter.Expression != "" {
+		expressions = append(expressions, fmt.Sprintf("(%s)", config.ValidatorArgs.Filter.Expression))
+	}
+
+	if config.ValidatorArgs.Filter.Field != "" && config.ValidatorArgs.Filter.Value != "" {
+		operator := ""
+		switch strings.ToLower(config.ValidatorArgs.Filter.Mode) {
+		case "equals":
+			operator = "=="
+		case "contains", "":
+			operator = "~"
+		default:
+			return nil, fmt.Errorf("unknown filter mode %q - valid modes: equals, contains", config.ValidatorArgs.Filter.Mode)
+		}
+
+		expressions = append(expressions, fmt.Sprintf("%s %s %s", config.ValidatorArgs.Filter.Field, operator, strconv.Quote(config.ValidatorArgs.Filter.Value)))
+	}
+
+	if len(expressions) == 0 {
+		return nil, nil
+	}
+
+	return ParseFilter(strings.Join(expressions, " and "))
+}
+
+// ParseFilter - parse a filter expression
+func ParseFilter(expression string) (FilterExpression, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &filterParser{tokens: tokens}
+	filter, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !parser.done() {
+		return nil, fmt.Errorf("unexpected %q in filter expression", parser.peek().text)
+	}
+
+	return filter, nil
+}
+
+type tokenKind int
+
+const (
+	identifierToken tokenKind = iota
+	numberToken
+	stringToken
+	regexToken
+	operatorToken
+	openParenToken
+	closeParenToken
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+}
+
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	tokens := []filterToken{}
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, filterToken{openParenToken, "("})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, filterToken{closeParenToken, ")"})
+			i++
+
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string in filter expression: %s", string(runes[i:]))
+			}
+
+			text := string(runes[i+1 : end])
+			if r == '"' {
+				unquoted, err := strconv.Unquote(string(runes[i : end+1]))
+				if err != nil {
+					return nil, fmt.Errorf("invalid string %s in filter expression", string(runes[i:end+1]))
+				}
+				text = unquoted
+			}
+
+			tokens = append(tokens, filterToken{stringToken, text})
+			i = end + 1
+
+		case r == '/':
+			end := i + 1
+			for end < len(runes) && runes[end] != '/' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated regular expression in filter expression: %s", string(runes[i:]))
+			}
+
+			pattern := string(runes[i+1 : end])
+			end++
+			// Support a trailing i flag for case insensitive matching, e.g. /foo/i
+			if end < len(runes) && runes[end] == 'i' && (end+1 == len(runes) || !isIdentifierRune(runes[end+1])) {
+				pattern = "(?i)" + pattern
+				end++
+			}
+
+			tokens = append(tokens, filterToken{regexToken, pattern})
+			i = end
+
+		case strings.ContainsRune("=!<>~&|", r):
+			end := i + 1
+			for end < len(runes) && strings.ContainsRune("=<>~&|", runes[end]) {
+				end++
+			}
+
+			operator := string(runes[i:end])
+			switch operator {
+			case "&&":
+				tokens = append(tokens, filterToken{identifierToken, "and"})
+			case "||":
+				tokens = append(tokens, filterToken{identifierToken, "or"})
+			case "!":
+				tokens = append(tokens, filterToken{identifierToken, "not"})
+			case "=", "==", "!=", ">", ">=", "<", "<=", "~", "!~", "=~":
+				if operator == "=" {
+					operator = "=="
+				} else if operator == "=~" {
+					operator = "~"
+				}
+				tokens = append(tokens, filterToken{operatorToken, operator})
+			default:
+				return nil, fmt.Errorf("unknown operator %q in filter expression", operator)
+			}
+			i = end
+
+		case unicode.IsDigit(r) || r == '-' || r == '.':
+			end := i + 1
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' || runes[end] == '_') {
+				end++
+			}
+			tokens = append(tokens, filterToken{numberToken, strings.ReplaceAll(string(runes[i:end]), "_", "")})
+			i = end
+
+		case isIdentifierRune(r):
+			end := i + 1
+			for end < len(runes) && isIdentifierRune(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, filterToken{identifierToken, string(runes[i:end])})
+			i = end
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q in filter expression", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-' || r == ':'
+}
+
+type filterParser struct {
+	tokens   []filterToken
+	position int
+}
+
+func (p *filterParser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.position]
+}
+
+func (p *filterParser) next() filterToken {
+	token := p.tokens[p.position]
+	p.position++
+	return token
+}
+
+func (p *filterParser) isKeyword(keyword string) bool {
+	return !p.done() && p.peek().kind == identifierToken && strings.ToLower(p.peek().text) == keyword
+}
+
+func (p *filterParser) parseOr() (FilterExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpression{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (FilterExpression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpression{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (FilterExpression, error) {
+	if p.isKeyword("not") {
+		p.next()
+		expression, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpression{expression}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (FilterExpression, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of filter expression")
+	}
+
+	token := p.next()
+
+	switch token.kind {
+	case openParenToken:
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().kind != closeParenToken {
+			return nil, fmt.Errorf("missing closing parenthesis in filter expression")
+		}
+		p.next()
+		return expression, nil
+
+	case identifierToken:
+		return p.parseComparison(token.text)
+
+	default:
+		return nil, fmt.Errorf("expected a field name but got %q in filter expression", token.text)
+	}
+}
+
+func (p *filterParser) parseComparison(fieldName string) (FilterExpression, error) {
+	name := strings.ToLower(fieldName)
+	field, ok := filterFields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter field %q - valid fields: %s", fieldName, strings.Join(FilterFieldNames(), ", "))
+	}
+
+	if p.done() || p.peek().kind != operatorToken {
+		if field.kind != boolField {
+			return nil, fmt.Errorf("the %s field %q has to be compared to a value", field.kind, fieldName)
+		}
+		return comparisonExpression{field: field, operator: "==", value: true}, nil
+	}
+
+	operator := p.next().text
+	if p.done() {
+		return nil, fmt.Errorf("missing value for field %q in filter expression", fieldName)
+	}
+	valueToken := p.next()
+
+	switch field.kind {
+	case boolField:
+		if operator != "==" && operator != "!=" {
+			return nil, fmt.Errorf("operator %s can't be used with the boolean field %q", operator, fieldName)
+		}
+		value, err := strconv.ParseBool(valueToken.text)
+		if err != nil || (valueToken.kind != identifierToken && valueToken.kind != stringToken) {
+			return nil, fmt.Errorf("invalid boolean value %q for field %q", valueToken.text, fieldName)
+		}
+		return comparisonExpression{field: field, operator: operator, value: value}, nil
+
+	case numberField:
+		if operator == "~" || operator == "!~" {
+			return nil, fmt.Errorf("operator %s can't be used with the number field %q", operator, fieldName)
+		}
+		if valueToken.kind != numberToken && valueToken.kind != stringToken {
+			return nil, fmt.Errorf("invalid number %q for field %q", valueToken.text, fieldName)
+		}
+		value, err := numeric.NewDecFromStr(valueToken.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q for field %q", valueToken.text, fieldName)
+		}
+		return comparisonExpression{field: field, operator: operator, value: value}, nil
+
+	default:
+		switch operator {
+		case "==", "!=":
+			if valueToken.kind == regexToken {
+				return nil, fmt.Errorf("regular expressions can only be used with the ~ and !~ operators (field %q)", fieldName)
+			}
+			return comparisonExpression{field: field, operator: operator, value: strings.ToLower(valueToken.text)}, nil
+		case "~", "!~":
+			if valueToken.kind == regexToken {
+				regex, err := regexp.Compile(valueToken.text)
+				if err != nil {
+					return nil, fmt.Errorf("invalid regular expression /%s/ for field %q - error: %s", valueToken.text, fieldName, err.Error())
+				}
+				return comparisonExpression{field: field, operator: operator, value: regex}, nil
+			}
+			return comparisonExpression{field: field, operator: operator, value: strings.ToLower(valueToken.text)}, nil
+		default:
+			return nil, fmt.Errorf("operator %s can't be used with the string field %q", operator, fieldName)
+		}
+	}
+}
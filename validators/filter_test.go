@@ -0,0 +1,172 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+func testValidator(name string, totalDelegation int64, keys int, elected bool) sdkValidator.RPCValidatorResult {
+	validatorResult := sdkValidator.RPCValidatorResult{
+		CurrentlyInCommittee: elected,
+		TotalDelegation:      numeric.NewDec(totalDelegation),
+	}
+	validatorResult.Validator.Name = name
+	validatorResult.Validator.Address = "one1" + strings.ToLower(name)
+
+	for i := 0; i < keys; i++ {
+		validatorResult.Validator.BLSPublicKeys = append(validatorResult.Validator.BLSPublicKeys, "key")
+	}
+
+	return validatorResult
+}
+
+func TestParseFilter(t *testing.T) {
+	small := testValidator("Small", 100, 1, false)
+	large := testValidator("Large", 10000, 2, true)
+	foo := testValidator("FooBar", 5000, 1, true)
+
+	tests := []struct {
+		expression string
+		matches    map[string]bool
+	}{
+		{"elected", map[string]bool{"Small": false, "Large": true, "FooBar": true}},
+		{"not elected", map[string]bool{"Small": true, "Large": false, "FooBar": false}},
+		{"!elected", map[string]bool{"Small": true, "Large": false, "FooBar": false}},
+		{"elected == false", map[string]bool{"Small": true, "Large": false, "FooBar": false}},
+		{"total_delegation > 1000", map[string]bool{"Small": false, "Large": true, "FooBar": true}},
+		{"total_delegation >= 10_000", map[string]bool{"Small": false, "Large": true, "FooBar": false}},
+		{"total_delegation = 100", map[string]bool{"Small": true, "Large": false, "FooBar": false}},
+		{"bls_keys != 1", map[string]bool{"Small": false, "Large": true, "FooBar": false}},
+		{"name == 'large'", map[string]bool{"Small": false, "Large": true, "FooBar": false}},
+		{`name ~ "bar"`, map[string]bool{"Small": false, "Large": false, "FooBar": true}},
+		{"name ~ /^Foo/", map[string]bool{"Small": false, "Large": false, "FooBar": true}},
+		{"name ~ /^foo/", map[string]bool{"Small": false, "Large": false, "FooBar": false}},
+		{"name ~ /^foo/i", map[string]bool{"Small": false, "Large": false, "FooBar": true}},
+		{"name !~ /a/", map[string]bool{"Small": false, "Large": false, "FooBar": false}},
+		// and binds tighter than or
+		{"name == small or elected and bls_keys == 2", map[string]bool{"Small": true, "Large": true, "FooBar": false}},
+		{"(name == small or elected) and bls_keys == 1", map[string]bool{"Small": true, "Large": false, "FooBar": true}},
+		{"not (elected or total_delegation < 200)", map[string]bool{"Small": false, "Large": false, "FooBar": false}},
+		{"not elected or bls_keys == 2", map[string]bool{"Small": true, "Large": true, "FooBar": false}},
+		{"elected && (bls_keys > 1 || name ~ foo)", map[string]bool{"Small": false, "Large": true, "FooBar": true}},
+		{"ELECTED AND Total_Delegation < 6000", map[string]bool{"Small": false, "Large": false, "FooBar": true}},
+	}
+
+	for _, test := range tests {
+		filter, err := ParseFilter(test.expression)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) returned an unexpected error: %s", test.expression, err.Error())
+			continue
+		}
+
+		for _, validatorResult := range []sdkValidator.RPCValidatorResult{small, large, foo} {
+			expected := test.matches[validatorResult.Validator.Name]
+			if actual := filter.Matches(validatorResult); actual != expected {
+				t.Errorf("ParseFilter(%q).Matches(%s) = %t, expected %t", test.expression, validatorResult.Validator.Name, actual, expected)
+			}
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"unknown_field > 1", "unknown filter field"},
+		{"total_delegation", "has to be compared to a value"},
+		{"total_delegation >", "missing value"},
+		{"total_delegation > abc", "invalid number"},
+		{"total_delegation ~ 1", "can't be used with the number field"},
+		{"elected > true", "can't be used with the boolean field"},
+		{"elected == maybe", "invalid boolean value"},
+		{"name > foo", "can't be used with the string field"},
+		{"name == /foo/", "regular expressions can only be used"},
+		{"name ~ /(/", "invalid regular expression"},
+		{"name ~ /foo", "unterminated regular expression"},
+		{`name == "foo`, "unterminated string"},
+		{"(elected", "missing closing parenthesis"},
+		{"elected)", "unexpected \")\""},
+		{"elected and", "unexpected end of filter expression"},
+		{"elected or 5", "expected a field name"},
+		{"elected or or elected", "unknown filter field \"or\""},
+		{"name === foo", "unknown operator"},
+		{"name == foo $", "unexpected character"},
+		{"", "unexpected end of filter expression"},
+	}
+
+	for _, test := range tests {
+		filter, err := ParseFilter(test.expression)
+		if err == nil {
+			t.Errorf("ParseFilter(%q) = %v, expected an error containing %q", test.expression, filter, test.err)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("ParseFilter(%q) returned the error %q, expected an error containing %q", test.expression, err.Error(), test.err)
+		}
+	}
+}
+
+func TestCompileFilter(t *testing.T) {
+	defer func(filter config.FilterFlags) { config.ValidatorArgs.Filter = filter }(config.ValidatorArgs.Filter)
+
+	small := testValidator("Small", 100, 1, false)
+	foo := testValidator("FooBar", 5000, 1, true)
+
+	tests := []struct {
+		flags   config.FilterFlags
+		isNil   bool
+		err     string
+		matches map[string]bool
+	}{
+		{flags: config.FilterFlags{}, isNil: true},
+		{flags: config.FilterFlags{Field: "name"}, isNil: true},
+		{flags: config.FilterFlags{Field: "name", Value: "bar"}, matches: map[string]bool{"Small": false, "FooBar": true}},
+		{flags: config.FilterFlags{Field: "name", Value: "bar", Mode: "contains"}, matches: map[string]bool{"Small": false, "FooBar": true}},
+		{flags: config.FilterFlags{Field: "name", Value: "bar", Mode: "equals"}, matches: map[string]bool{"Small": false, "FooBar": false}},
+		{flags: config.FilterFlags{Field: "name", Value: "FooBar", Mode: "Equals"}, matches: map[string]bool{"Small": false, "FooBar": true}},
+		// Values are quoted so that they can't inject expressions
+		{flags: config.FilterFlags{Field: "name", Value: `x" or elected or name == "y`, Mode: "equals"}, matches: map[string]bool{"Small": false, "FooBar": false}},
+		{flags: config.FilterFlags{Field: "name", Value: "bar", Mode: "prefix"}, err: "unknown filter mode"},
+		{flags: config.FilterFlags{Field: "unknown", Value: "bar"}, err: "unknown filter field"},
+		// The legacy flags are combined with the expression using and
+		{flags: config.FilterFlags{Expression: "elected or total_delegation < 200", Field: "name", Value: "small"}, matches: map[string]bool{"Small": true, "FooBar": false}},
+		{flags: config.FilterFlags{Expression: "elected or"}, err: "expected a field name"},
+	}
+
+	for _, test := range tests {
+		config.ValidatorArgs.Filter = test.flags
+
+		filter, err := CompileFilter()
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("CompileFilter() with flags %+v returned the error %v, expected an error containing %q", test.flags, err, test.err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CompileFilter() with flags %+v returned an unexpected error: %s", test.flags, err.Error())
+			continue
+		}
+
+		if test.isNil {
+			if filter != nil {
+				t.Errorf("CompileFilter() with flags %+v = %v, expected nil", test.flags, filter)
+			}
+			continue
+		}
+
+		for _, validatorResult := range []sdkValidator.RPCValidatorResult{small, foo} {
+			expected := test.matches[validatorResult.Validator.Name]
+			if actual := filter.Matches(validatorResult); actual != expected {
+				t.Errorf("CompileFilter() with flags %+v matches %s = %t, expected %t", test.flags, validatorResult.Validator.Name, actual, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package validators
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/utils"
+	"github.com/wcharczuk/go-chart"
+)
+
+// BLSKey - a BLS key and the shard it's assigned to
+type BLSKey struct {
+	BLSPublicKey string `json:"bls-public-key"`
+	ShardID      uint32 `json:"shard-id"`
+	Elected      bool   `json:"elected"`
+}
+
+// ValidatorKeys - the distribution of a validator's BLS keys and elected slots across shards
+type ValidatorKeys struct {
+	Name                 string   `json:"name"`
+	Address              string   `json:"address"`
+	HexAddress           string   `json:"hex-address,omitempty"`
+	Keys                 []BLSKey `json:"bls-keys"`
+	KeysPerShard         []int    `json:"keys-per-shard"`
+	ElectedSlotsPerShard []int    `json:"elected-slots-per-shard"`
+	Unbalanced           bool     `json:"unbalanced"`
+}
+
+// Keys - analyze the distribution of BLS keys and elected slots across shards
+func Keys() error {
+	fmt.Printf("Will analyze BLS keys and slots per shard - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	shardCount := config.Configuration.Network.API.ShardCount
+	if shardCount <= 0 {
+		return fmt.Errorf("failed to identify the shard count for network %s", config.Configuration.Network.Name)
+	}
+
+	validatorResults, err := filteredInformation()
+	if err != nil {
+		return err
+	}
+
+	validatorKeys := []ValidatorKeys{}
+	totalKeys := make([]int, shardCount)
+	totalElectedSlots := make([]int, shardCount)
+
+	for _, validatorResult := range validatorResults {
+		elected := make(map[string]bool)
+		for _, electedKey := range validatorResult.ElectedKeys() {
+			elected[normalizeKey(electedKey.BLSPublicKey)] = true
+		}
+
+		keys := ValidatorKeys{
+			Name:                 validatorResult.Validator.Name,
+			Address:              formatAddress(validatorResult.Validator.Address),
+			HexAddress:           hexAddress(validatorResult.Validator.Address),
+			KeysPerShard:         make([]int, shardCount),
+			ElectedSlotsPerShard: make([]int, shardCount),
+		}
+
+		for _, blsKey := range validatorResult.Validator.BLSPublicKeys {
+			shardID, err := keyShard(blsKey, shardCount)
+			if err != nil {
+				return fmt.Errorf("failed to identify the shard of bls key %s for validator %s - error: %s", blsKey, validatorResult.Validator.Address, err.Error())
+			}
+
+			key := BLSKey{BLSPublicKey: blsKey, ShardID: shardID, Elected: elected[normalizeKey(blsKey)]}
+			keys.Keys = append(keys.Keys, key)
+
+			keys.KeysPerShard[shardID]++
+			totalKeys[shardID]++
+			if key.Elected {
+				keys.ElectedSlotsPerShard[shardID]++
+				totalElectedSlots[shardID]++
+			}
+		}
+
+		keys.Unbalanced = unbalanced(keys.KeysPerShard)
+		validatorKeys = append(validatorKeys, keys)
+	}
+
+	for shardID := 0; shardID < shardCount; shardID++ {
+		fmt.Printf("Shard %d - bls keys: %d, elected slots: %d\n", shardID, totalKeys[shardID], totalElectedSlots[shardID])
+	}
+
+	unbalancedCount := 0
+	for _, keys := range validatorKeys {
+		fmt.Printf("Validator %s (%s) - keys per shard: %v, elected slots per shard: %v\n", keys.Name, keys.Address, keys.KeysPerShard, keys.ElectedSlotsPerShard)
+		if keys.Unbalanced {
+			unbalancedCount++
+		}
+	}
+
+	if unbalancedCount > 0 {
+		fmt.Printf("Found a total of %d validators with bls keys unbalanced across shards:\n", unbalancedCount)
+		for _, keys := range validatorKeys {
+			if keys.Unbalanced {
+				fmt.Printf("Validator %s (%s) - keys per shard: %v\n", keys.Name, keys.Address, keys.KeysPerShard)
+			}
+		}
+	}
+
+	if err = chartSlots(fmt.Sprintf("validators/%s-slots.png", strings.ToLower(config.Configuration.Network.Name)), totalKeys, totalElectedSlots); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportKeysToCSV(validatorKeys, shardCount)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported bls key data to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/keys-%s-UTC.json", utils.FormattedTimeString(time.Now().UTC())), validatorKeys)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported bls key data to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// keyShard - BLS keys are assigned to the shard given by the key modulo the shard count
+func keyShard(blsKey string, shardCount int) (uint32, error) {
+	if shardCount <= 0 {
+		return 0, fmt.Errorf("invalid shard count %d", shardCount)
+	}
+
+	bytes, err := hex.DecodeString(normalizeKey(blsKey))
+	if err != nil {
+		return 0, err
+	}
+
+	shardID := new(big.Int).Mod(new(big.Int).SetBytes(bytes), big.NewInt(int64(shardCount)))
+
+	return uint32(shardID.Uint64()), nil
+}
+
+func normalizeKey(blsKey string) string {
+	return strings.TrimPrefix(strings.ToLower(blsKey), "0x")
+}
+
+// unbalanced - keys are considered balanced when no shard has more than one key more than any other shard
+func unbalanced(keysPerShard []int) bool {
+	counts := []float64{}
+	for _, count := range keysPerShard {
+		counts = append(counts, float64(count))
+	}
+
+	min, max := utils.MinMax(counts)
+
+	return max-min > 1
+}
+
+// chartSlots - chart the bls keys per shard as stacked bars, split into elected and not elected slots
+func chartSlots(fileName string, totalKeys []int, totalElectedSlots []int) error {
+	bars := []chart.StackedBar{}
+	for shardID := range totalKeys {
+		bar := chart.StackedBar{Name: fmt.Sprintf("Shard %d", shardID)}
+
+		notElected := totalKeys[shardID] - totalElectedSlots[shardID]
+		if totalElectedSlots[shardID] > 0 {
+			bar.Values = append(bar.Values, chart.Value{Label: fmt.Sprintf("Elected: %d", totalElectedSlots[shardID]), Value: float64(totalElectedSlots[shardID])})
+		}
+		if notElected > 0 {
+			bar.Values = append(bar.Values, chart.Value{Label: fmt.Sprintf("Not elected: %d", notElected), Value: float64(notElected)})
+		}
+
+		bars = append(bars, bar)
+	}
+
+	return charts.GenerateStackedBarChart(fileName, "Open Staking BLS Keys & Elected Slots per Shard", bars)
+}
+
+func exportKeysToCSV(validatorKeys []ValidatorKeys, shardCount int) (string, error) {
+	fileName := fmt.Sprintf("validators/keys-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	header := []string{"Name"}
+	header = append(header, addressHeaders("Address")...)
+	header = append(header, "BLS Keys")
+	for shardID := 0; shardID < shardCount; shardID++ {
+		header = append(header, fmt.Sprintf("Shard %d Keys", shardID), fmt.Sprintf("Shard %d Elected Slots", shardID))
+	}
+	header = append(header, "Unbalanced")
+
+	rows := [][]string{header}
+
+	for _, keys := range validatorKeys {
+		blsKeys := []string{}
+		for _, key := range keys.Keys {
+			blsKeys = append(blsKeys, fmt.Sprintf("%s (shard %d, elected: %t)", key.BLSPublicKey, key.ShardID, key.Elected))
+		}
+
+		row := []string{keys.Name}
+		row = append(row, addressColumns(keys.Address, keys.HexAddress)...)
+		row = append(row, strings.Join(blsKeys, "\n"))
+		for shardID := 0; shardID < shardCount; shardID++ {
+			row = append(row, fmt.Sprintf("%d", keys.KeysPerShard[shardID]), fmt.Sprintf("%d", keys.ElectedSlotsPerShard[shardID]))
+		}
+		row = append(row, fmt.Sprintf("%t", keys.Unbalanced))
+
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
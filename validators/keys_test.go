@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeyShard(t *testing.T) {
+	// BLS public keys are 48 bytes, only the trailing bytes matter for the tests
+	prefix := strings.Repeat("00", 46)
+
+	tests := []struct {
+		blsKey     string
+		shardCount int
+		expected   uint32
+	}{
+		{prefix + "0000", 4, 0},
+		{prefix + "0001", 4, 1},
+		{prefix + "0007", 4, 3},
+		{prefix + "0100", 4, 0},
+		{prefix + "0101", 3, 2},
+		{"0x" + prefix + "0006", 4, 2},
+		{"0X" + prefix + "0006", 4, 2},
+		{strings.ToUpper(prefix + "00ff"), 4, 3},
+		{strings.Repeat("ff", 48), 4, 3},
+		{prefix + "0007", 1, 0},
+	}
+
+	for _, test := range tests {
+		actual, err := keyShard(test.blsKey, test.shardCount)
+		if err != nil {
+			t.Errorf("keyShard(%s, %d) returned an unexpected error: %s", test.blsKey, test.shardCount, err.Error())
+			continue
+		}
+
+		if actual != test.expected {
+			t.Errorf("keyShard(%s, %d) = %d, expected %d", test.blsKey, test.shardCount, actual, test.expected)
+		}
+	}
+}
+
+func TestKeyShardErrors(t *testing.T) {
+	tests := []struct {
+		blsKey     string
+		shardCount int
+	}{
+		{"not a key", 4},
+		{"abc", 4},
+		{strings.Repeat("00", 48), 0},
+	}
+
+	for _, test := range tests {
+		if actual, err := keyShard(test.blsKey, test.shardCount); err == nil {
+			t.Errorf("keyShard(%s, %d) = %d, expected an error", test.blsKey, test.shardCount, actual)
+		}
+	}
+}
+
+func TestUnbalanced(t *testing.T) {
+	tests := []struct {
+		keysPerShard []int
+		expected     bool
+	}{
+		{[]int{}, false},
+		{[]int{1, 1, 1, 1}, false},
+		{[]int{2, 1, 1, 1}, false},
+		{[]int{2, 0, 1, 1}, true},
+		{[]int{4, 0, 0, 0}, true},
+	}
+
+	for _, test := range tests {
+		if actual := unbalanced(test.keysPerShard); actual != test.expected {
+			t.Errorf("unbalanced(%v) = %t, expected %t", test.keysPerShard, actual, test.expected)
+		}
+	}
+}
@@ -2,56 +2,184 @@ package validators
 
 import (
 	"fmt"
-	"strconv"
+	"math"
+	"sort"
 	"strings"
 
 	"github.com/SebastianJ/harmony-stats/charts"
 	"github.com/SebastianJ/harmony-stats/config"
 	"github.com/SebastianJ/harmony-stats/utils"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
 	"github.com/wcharczuk/go-chart"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+type leaderboardMetric struct {
+	title     string
+	yAxisName string
+	value     func(validatorResult sdkValidator.RPCValidatorResult) float64
+	format    func(value float64) string
+}
+
+var (
+	numberPrinter = message.NewPrinter(language.English)
+
+	leaderboardMetrics = map[string]leaderboardMetric{
+		"rewards": {
+			title:     "Lifetime Rewards",
+			yAxisName: "Rewards",
+			value: func(r sdkValidator.RPCValidatorResult) float64 {
+				return utils.DecToFloat(r.Lifetime.RewardAccumulated)
+			},
+			format: formatONE,
+		},
+		"delegation": {
+			title:     "Total Delegation",
+			yAxisName: "Delegation",
+			value: func(r sdkValidator.RPCValidatorResult) float64 {
+				return utils.DecToFloat(r.TotalDelegation)
+			},
+			format: formatONE,
+		},
+		"delegators": {
+			title:     "Delegators",
+			yAxisName: "Delegators",
+			value: func(r sdkValidator.RPCValidatorResult) float64 {
+				delegators := 0
+				for _, delegation := range r.Validator.Delegations {
+					if utils.DecToFloat(delegation.Amount) > 0 {
+						delegators++
+					}
+				}
+				return float64(delegators)
+			},
+			format: formatCount,
+		},
+		"uptime": {
+			title:     "Lifetime Uptime",
+			yAxisName: "Uptime",
+			value: func(r sdkValidator.RPCValidatorResult) float64 {
+				return CalculateUptime(r).LifetimeUptime
+			},
+			format: formatPercentage,
+		},
+		"apr": {
+			title:     "APR",
+			yAxisName: "APR",
+			value: func(r sdkValidator.RPCValidatorResult) float64 {
+				return utils.DecToFloat(r.Lifetime.APR) * 100
+			},
+			format: formatPercentage,
+		},
+		"bls-keys": {
+			title:     "BLS Keys",
+			yAxisName: "BLS Keys",
+			value: func(r sdkValidator.RPCValidatorResult) float64 {
+				return float64(len(r.Validator.BLSPublicKeys))
+			},
+			format: formatCount,
+		},
+	}
 )
 
 // Leaderboard - generate validator leaderboard graph
 func Leaderboard() error {
 	fmt.Printf("Will generate a graph of the validator leaderboard - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
 
-	validatorResults, err := AcceptableBLS()
+	metricName := strings.ToLower(config.ValidatorArgs.Leaderboard.Metric)
+	metric, ok := leaderboardMetrics[metricName]
+	if !ok {
+		return fmt.Errorf("unknown leaderboard metric %q - valid metrics: %s", config.ValidatorArgs.Leaderboard.Metric, strings.Join(LeaderboardMetricNames(), ", "))
+	}
+
+	ascending := false
+	switch strings.ToLower(config.ValidatorArgs.Leaderboard.Order) {
+	case "asc":
+		ascending = true
+	case "desc":
+	default:
+		return fmt.Errorf("unknown leaderboard order %q - valid orders: asc, desc", config.ValidatorArgs.Leaderboard.Order)
+	}
+
+	validatorResults, err := Filtered()
 	if err != nil {
 		return err
 	}
 
+	if config.ValidatorArgs.Leaderboard.BLSKeys > 0 {
+		validatorResults = applyBLSKeyCountFilter(validatorResults, config.ValidatorArgs.Leaderboard.BLSKeys)
+	}
+
 	fmt.Printf("Found a total of %d validators eligible to use for the leaderboard\n", len(validatorResults))
 
-	limit := 20
+	values := make(map[string]float64)
+	for _, validatorResult := range validatorResults {
+		values[validatorResult.Validator.Address] = metric.value(validatorResult)
+	}
 
-	bars := []chart.Value{}
-	fileName := fmt.Sprintf("validators/%s-leaderboard.png", strings.ToLower(config.Configuration.Network.Name))
-	for i, validatorResult := range validatorResults {
-		if i < limit {
-			bar := chart.Value{}
-			bar.Label = formatForLabel(validatorResult.Validator.Name)
-			rewards := fmt.Sprintf("%f", validatorResult.Lifetime.RewardAccumulated)
-			fmt.Printf("Rewards: %s", rewards)
-			floatRewards, err := strconv.ParseFloat(rewards, 32)
-			if err != nil {
-				return err
-			}
-			fmt.Printf("FloatRewards: %f", floatRewards)
-
-			bar.Value = floatRewards
-			bars = append(bars, bar)
-		} else {
-			break
+	sort.SliceStable(validatorResults, func(i, j int) bool {
+		if ascending {
+			return values[validatorResults[i].Validator.Address] < values[validatorResults[j].Validator.Address]
 		}
+		return values[validatorResults[i].Validator.Address] > values[validatorResults[j].Validator.Address]
+	})
+
+	limit := config.ValidatorArgs.Leaderboard.Limit
+	if limit <= 0 || limit > len(validatorResults) {
+		limit = len(validatorResults)
 	}
 
-	if err = charts.GenerateBarChart(fileName, "Open Staking Validator Leaderboard - Lifetime Rewards", bars); err != nil {
+	bars := []chart.Value{}
+	for index, validatorResult := range validatorResults[:limit] {
+		value := values[validatorResult.Validator.Address]
+		address := formatAddress(validatorResult.Validator.Address)
+		fmt.Printf("#%d %s (%s) - %s: %s\n", index+1, validatorResult.Validator.Name, address, strings.ToLower(metric.title), metric.format(value))
+
+		bars = append(bars, chart.Value{
+			Label: validatorLabel(validatorResult.Validator.Name, address),
+			Value: value,
+		})
+	}
+
+	title := fmt.Sprintf("Open Staking Validator Leaderboard - %s", metric.title)
+	if ascending {
+		title = fmt.Sprintf("%s (lowest first)", title)
+	}
+
+	fileName := fmt.Sprintf("validators/%s-leaderboard-%s.png", strings.ToLower(config.Configuration.Network.Name), metricName)
+	if err = charts.GenerateBarChart(fileName, title, metric.yAxisName, func(v interface{}) string {
+		return metric.format(v.(float64))
+	}, bars); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// LeaderboardMetricNames - return the names of all supported leaderboard metrics
+func LeaderboardMetricNames() []string {
+	names := []string{}
+	for name := range leaderboardMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func formatONE(value float64) string {
+	return numberPrinter.Sprintf("%d ONE", int(math.RoundToEven(value)))
+}
+
+func formatCount(value float64) string {
+	return numberPrinter.Sprintf("%d", int(math.RoundToEven(value)))
+}
+
+func formatPercentage(value float64) string {
+	return fmt.Sprintf("%.1f%%", value)
+}
+
 func formatForLabel(name string) string {
 	if len(name) >= 10 {
 		name = fixNameWrapping(name)
@@ -0,0 +1,217 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	chart "github.com/wcharczuk/go-chart"
+)
+
+// NetworkOverview - network wide staking summary
+type NetworkOverview struct {
+	Time                 time.Time `json:"time"`
+	Network              string    `json:"network"`
+	CurrentEpoch         uint64    `json:"current-epoch"`
+	Validators           int       `json:"validators"`
+	ElectedValidators    int       `json:"elected-validators"`
+	BLSKeys              int       `json:"bls-keys"`
+	ElectedBLSKeys       int       `json:"elected-bls-keys"`
+	TotalStaked          float64   `json:"total-staked"`
+	TotalSelfDelegation  float64   `json:"total-self-delegation"`
+	TotalDelegated       float64   `json:"total-delegated"`
+	MedianStake          float64   `json:"median-stake"`
+	EffectiveMedianStake float64   `json:"effective-median-stake"`
+	TotalLifetimeRewards float64   `json:"total-lifetime-rewards"`
+	Dashboard            string    `json:"dashboard"`
+}
+
+// Overview - summarize network wide staking statistics and combine the most relevant charts into a single dashboard
+// The total staked amount includes self delegations, the total delegated amount only covers delegations from other wallets
+func Overview() error {
+	fmt.Printf("Will generate a staking overview - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	shardCount := config.Configuration.Network.API.ShardCount
+	if shardCount <= 0 {
+		return fmt.Errorf("failed to identify the shard count for network %s", config.Configuration.Network.Name)
+	}
+
+	node := config.Configuration.Network.API.NodeAddress(0)
+
+	header, err := rpc.GetLatestHeader(node)
+	if err != nil {
+		return err
+	}
+
+	validatorResults, err := allInformation()
+	if err != nil {
+		return err
+	}
+
+	overview := NetworkOverview{
+		Time:         time.Now().UTC(),
+		Network:      config.Configuration.Network.Name,
+		CurrentEpoch: header.Epoch,
+		Validators:   len(validatorResults),
+	}
+
+	totalKeys := make([]int, shardCount)
+	totalElectedSlots := make([]int, shardCount)
+	electedStakes := []float64{}
+	commissions := []Commission{}
+	shares := []StakeShare{}
+
+	for _, validatorResult := range validatorResults {
+		validator := validatorResult.Validator
+		totalDelegation := utils.DecToFloat(validatorResult.TotalDelegation)
+
+		overview.TotalStaked += totalDelegation
+		overview.TotalSelfDelegation += utils.DecToFloat(selfDelegation(validator).Amount)
+		overview.TotalLifetimeRewards += utils.DecToFloat(validatorResult.Lifetime.RewardAccumulated)
+		overview.BLSKeys += len(validator.BLSPublicKeys)
+
+		elected := make(map[string]bool)
+		for _, electedKey := range validatorResult.ElectedKeys() {
+			elected[normalizeKey(electedKey.BLSPublicKey)] = true
+		}
+
+		for _, blsKey := range validator.BLSPublicKeys {
+			shardID, err := keyShard(blsKey, shardCount)
+			if err != nil {
+				return fmt.Errorf("failed to identify the shard of bls key %s for validator %s - error: %s", blsKey, validator.Address, err.Error())
+			}
+
+			totalKeys[shardID]++
+			if elected[normalizeKey(blsKey)] {
+				totalElectedSlots[shardID]++
+				overview.ElectedBLSKeys++
+			}
+		}
+
+		if validatorResult.CurrentlyInCommittee {
+			overview.ElectedValidators++
+			electedStakes = append(electedStakes, totalDelegation)
+			commissions = append(commissions, Commission{Name: validator.Name, Address: validator.Address, Rate: utils.DecToFloat(validator.Rate)})
+			shares = append(shares, StakeShare{Name: validator.Name, Address: formatAddress(validator.Address), Stake: totalDelegation})
+		}
+	}
+
+	overview.TotalDelegated = overview.TotalStaked - overview.TotalSelfDelegation
+	if len(electedStakes) > 0 {
+		overview.MedianStake = utils.Percentile(electedStakes, 50)
+	}
+
+	// The effective median stake is only available on the beacon chain, the rest of the overview is still useful without it
+	snapshot, err := rpc.GetMedianStakeSnapshot(node)
+	if err != nil {
+		fmt.Printf("Failed to retrieve the median stake snapshot - error: %s\n", err.Error())
+	} else {
+		overview.EffectiveMedianStake = utils.DecToFloat(snapshot.MedianStake)
+	}
+
+	details := overviewDetails(overview)
+	for _, detail := range details {
+		fmt.Println(detail)
+	}
+
+	panels, err := overviewPanels(overview, shares, commissions, totalKeys, totalElectedSlots)
+	if err != nil {
+		return err
+	}
+
+	overview.Dashboard = fmt.Sprintf("validators/%s-overview-%s.png", strings.ToLower(config.Configuration.Network.Name), utils.FormattedTimeString(overview.Time))
+	if err = charts.GenerateDashboardPNG(overview.Dashboard, fmt.Sprintf("Harmony Open Staking Overview - %s", strings.Title(overview.Network)), details, panels); err != nil {
+		return err
+	}
+	fmt.Printf("Successfully generated the staking overview dashboard %s\n", overview.Dashboard)
+
+	// The overview is always exported as json since it's meant to be used as part of other reports
+	jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/overview-%s-UTC.json", utils.FormattedTimeString(overview.Time)), overview)
+	if err != nil {
+		return err
+	} else if jsonPath != "" {
+		fmt.Printf("Successfully exported the staking overview to %s\n", jsonPath)
+	}
+
+	return nil
+}
+
+func overviewDetails(overview NetworkOverview) []string {
+	return []string{
+		fmt.Sprintf("Epoch: %d, generated at: %s", overview.CurrentEpoch, overview.Time.Format(time.RFC3339)),
+		fmt.Sprintf("Validators: %d, elected validators: %d, bls keys: %d, elected bls keys: %d", overview.Validators, overview.ElectedValidators, overview.BLSKeys, overview.ElectedBLSKeys),
+		fmt.Sprintf("Total staked: %s ONE, total delegated: %s ONE, total self delegation: %s ONE", formatONE(overview.TotalStaked), formatONE(overview.TotalDelegated), formatONE(overview.TotalSelfDelegation)),
+		fmt.Sprintf("Median stake (elected validators): %s ONE, effective median stake: %s ONE", formatONE(overview.MedianStake), formatONE(overview.EffectiveMedianStake)),
+		fmt.Sprintf("Total lifetime rewards: %s ONE", formatONE(overview.TotalLifetimeRewards)),
+	}
+}
+
+// overviewPanels - generate the charts making up the overview dashboard, charts that don't have any data to show are skipped
+// The panels use their own file names so that they don't overwrite the charts generated by the standalone commands
+func overviewPanels(overview NetworkOverview, shares []StakeShare, commissions []Commission, totalKeys []int, totalElectedSlots []int) ([]string, error) {
+	panels := []string{}
+
+	if len(shares) > 0 {
+		report := DecentralizationReport{Time: overview.Time, StakeSource: "total-delegation", Shares: shares}
+		calculateDecentralization(&report)
+
+		fileName := overviewPanelFileName("decentralization")
+		if err := chartCumulativeStake(fileName, report); err != nil {
+			return nil, err
+		}
+		panels = append(panels, fileName)
+
+		fileName = overviewPanelFileName("top-stakes")
+		if err := chartTopStakes(fileName, report); err != nil {
+			return nil, err
+		}
+		panels = append(panels, fileName)
+	}
+
+	if overview.BLSKeys > 0 {
+		fileName := overviewPanelFileName("slots")
+		if err := chartSlots(fileName, totalKeys, totalElectedSlots); err != nil {
+			return nil, err
+		}
+		panels = append(panels, fileName)
+	}
+
+	if len(commissions) > 0 {
+		fileName := overviewPanelFileName("commissions")
+		if err := chartCommissions(fileName, commissions); err != nil {
+			return nil, err
+		}
+		panels = append(panels, fileName)
+	}
+
+	return panels, nil
+}
+
+func overviewPanelFileName(panel string) string {
+	return fmt.Sprintf("validators/%s-overview-%s.png", strings.ToLower(config.Configuration.Network.Name), panel)
+}
+
+// chartTopStakes - chart the elected validators with the largest stake, the shares have to be ranked already
+func chartTopStakes(fileName string, report DecentralizationReport) error {
+	limit := config.ValidatorArgs.Overview.Limit
+	if limit <= 0 || limit > len(report.Shares) {
+		limit = len(report.Shares)
+	}
+
+	bars := []chart.Value{}
+	for _, share := range report.Shares[:limit] {
+		bars = append(bars, chart.Value{
+			Label: validatorLabel(share.Name, share.Address),
+			Value: share.Stake,
+		})
+	}
+
+	return charts.GenerateBarChart(fileName, fmt.Sprintf("Open Staking Top %d Elected Validators by Stake", limit), "Stake", func(v interface{}) string {
+		return formatONE(v.(float64))
+	}, bars)
+}
@@ -0,0 +1,82 @@
+package validators
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/rpc"
+)
+
+var (
+	// maxConcurrentPages - there are only a handful of validator pages even on mainnet, so there's no point in requesting more pages at once
+	maxConcurrentPages = 10
+)
+
+type pageResult struct {
+	page                 int
+	validatorInformation []rpc.ValidatorInformation
+	err                  error
+}
+
+// retrieveAllPages - retrieve all validator pages concurrently, batch by batch, until a page that isn't full is encountered
+// Pages are merged in page order so that the result is deterministic regardless of the order requests complete in
+func retrieveAllPages(node string) ([]rpc.ValidatorInformation, error) {
+	concurrency := config.Configuration.Concurrency
+	if concurrency <= 0 || concurrency > maxConcurrentPages {
+		concurrency = maxConcurrentPages
+	}
+
+	validatorInformation := []rpc.ValidatorInformation{}
+	for firstPage := 0; ; firstPage += concurrency {
+		results := make([]pageResult, concurrency)
+		var waitGroup sync.WaitGroup
+
+		for index := 0; index < concurrency; index++ {
+			waitGroup.Add(1)
+			go func(index int) {
+				defer waitGroup.Done()
+				page := firstPage + index
+				pageResults, err := retrievePage(node, page)
+				results[index] = pageResult{page: page, validatorInformation: pageResults, err: err}
+			}(index)
+		}
+
+		waitGroup.Wait()
+
+		for _, result := range results {
+			if result.err != nil {
+				return validatorInformation, fmt.Errorf("failed to retrieve validator page %d - error: %s", result.page, result.err.Error())
+			}
+
+			validatorInformation = append(validatorInformation, result.validatorInformation...)
+
+			if len(result.validatorInformation) < rpc.ValidatorPageSize {
+				return validatorInformation, nil
+			}
+		}
+	}
+}
+
+// retrievePage - retrieve a single validator page, retrying failed requests
+func retrievePage(node string, page int) (validatorInformation []rpc.ValidatorInformation, err error) {
+	attempts := config.ValidatorArgs.Retry.Attempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		validatorInformation, err = rpc.GetValidatorInformationPage(node, page)
+		if err == nil {
+			return validatorInformation, nil
+		}
+
+		if attempt < attempts {
+			fmt.Printf("Failed to retrieve validator page %d (attempt %d/%d) - error: %s - retrying in %d second(s)\n", page, attempt, attempts, err.Error(), config.ValidatorArgs.Retry.Wait)
+			time.Sleep(time.Duration(config.ValidatorArgs.Retry.Wait) * time.Second)
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,489 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/logger"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	"github.com/harmony-one/harmony/numeric"
+	chart "github.com/wcharczuk/go-chart"
+)
+
+// ValidatorProfile - everything there is to know about a single validator
+type ValidatorProfile struct {
+	Name                 string             `json:"name"`
+	Address              string             `json:"address"`
+	HexAddress           string             `json:"hex-address,omitempty"`
+	Identity             string             `json:"identity"`
+	Website              string             `json:"website"`
+	SecurityContact      string             `json:"security-contact"`
+	Details              string             `json:"details"`
+	Status               ValidatorStatus    `json:"status"`
+	SelfDelegation       float64            `json:"self-delegation"`
+	TotalDelegation      float64            `json:"total-delegation"`
+	LifetimeRewards      float64            `json:"lifetime-rewards"`
+	CommissionRate       float64            `json:"commission-rate"`
+	MaxRate              float64            `json:"max-rate"`
+	MaxChangeRate        float64            `json:"max-change-rate"`
+	WalletBalance        *float64           `json:"wallet-balance,omitempty"`
+	ShardBalances        map[uint32]float64 `json:"shard-balances,omitempty"`
+	Uptime               Uptime             `json:"uptime"`
+	Keys                 []BLSKey           `json:"bls-keys"`
+	KeysPerShard         []int              `json:"keys-per-shard"`
+	ElectedSlotsPerShard []int              `json:"elected-slots-per-shard"`
+	LastEpochInCommittee uint32             `json:"last-epoch-in-committee"`
+	Delegations          []DelegationRecord `json:"delegations"`
+	RewardsHistory       []RewardsSnapshot  `json:"rewards-history"`
+}
+
+// RewardsSnapshot - the lifetime rewards and total delegation of a validator at the time a snapshot was taken
+type RewardsSnapshot struct {
+	Time            time.Time `json:"time"`
+	Snapshot        string    `json:"snapshot"`
+	LifetimeRewards float64   `json:"lifetime-rewards"`
+	TotalDelegation float64   `json:"total-delegation"`
+}
+
+// Show - output a full profile of a single validator identified by its address or name
+func Show() error {
+	identifier := strings.TrimSpace(config.ValidatorArgs.Show.Validator)
+	if identifier == "" {
+		return fmt.Errorf("you need to specify the address or name of the validator to show")
+	}
+
+	fmt.Printf("Looking up validator %s - network: %s, mode: %s, node: %s\n", identifier, config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	information, err := findValidator(identifier)
+	if err != nil {
+		return err
+	}
+
+	profile, err := buildProfile(information)
+	if err != nil {
+		return err
+	}
+
+	outputProfile(profile)
+
+	if dashboard := strings.ToLower(config.ValidatorArgs.Show.Dashboard); dashboard != "" {
+		dashboardPath, err := generateProfileDashboard(profile, information.Validator.Address, dashboard)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Successfully generated the validator dashboard %s\n", dashboardPath)
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportDelegationsToCSV(profile.Delegations)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported the validator's delegations to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/validator-%s-%s-UTC.json", information.Validator.Address, utils.FormattedTimeString(time.Now().UTC())), profile)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported the validator profile to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// findValidator - addresses (bech32 or hex) are looked up directly, names are matched against all validators
+// An exact (case insensitive) name match is preferred, otherwise the name has to match a single validator partially
+func findValidator(identifier string) (rpc.ValidatorInformation, error) {
+	if address, err := utils.ToBech32Address(identifier); err == nil {
+		information, err := rpc.GetValidatorDetails(config.Configuration.Network.API.NodeAddress(0), address)
+		if err != nil {
+			return information, err
+		}
+
+		if information.Validator.Address == "" {
+			return information, fmt.Errorf("couldn't find a validator with the address %s", identifier)
+		}
+
+		return information, nil
+	}
+
+	validatorInformation, err := allInformation()
+	if err != nil {
+		return rpc.ValidatorInformation{}, err
+	}
+
+	matches := []rpc.ValidatorInformation{}
+	for _, information := range validatorInformation {
+		if strings.EqualFold(information.Validator.Name, identifier) {
+			return information, nil
+		}
+
+		if strings.Contains(strings.ToLower(information.Validator.Name), strings.ToLower(identifier)) {
+			matches = append(matches, information)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return rpc.ValidatorInformation{}, fmt.Errorf("couldn't find a validator named %s", identifier)
+	case 1:
+		return matches[0], nil
+	default:
+		names := []string{}
+		for _, match := range matches {
+			names = append(names, fmt.Sprintf("%s (%s)", match.Validator.Name, formatAddress(match.Validator.Address)))
+		}
+		return rpc.ValidatorInformation{}, fmt.Errorf("the name %s matches %d validators, please specify one of them: %s", identifier, len(matches), strings.Join(names, ", "))
+	}
+}
+
+// buildProfile - the validator information already includes the elected keys and booted status, so no further validator lookups are needed
+func buildProfile(information rpc.ValidatorInformation) (ValidatorProfile, error) {
+	validatorResult := information.RPCValidatorResult
+	validator := validatorResult.Validator
+
+	profile := ValidatorProfile{
+		Name:                 validator.Name,
+		Address:              formatAddress(validator.Address),
+		HexAddress:           hexAddress(validator.Address),
+		Identity:             validator.Identity,
+		Website:              validator.Website,
+		SecurityContact:      validator.SecurityContact,
+		Details:              validator.Details,
+		Status:               DetermineStatus(validatorResult, information.Booted()),
+		SelfDelegation:       utils.DecToFloat(selfDelegation(validator).Amount),
+		TotalDelegation:      utils.DecToFloat(validatorResult.TotalDelegation),
+		LifetimeRewards:      utils.DecToFloat(validatorResult.Lifetime.RewardAccumulated),
+		CommissionRate:       utils.DecToFloat(validator.Rate),
+		MaxRate:              utils.DecToFloat(validator.MaxRate),
+		MaxChangeRate:        utils.DecToFloat(validator.MaxChangeRate),
+		Uptime:               CalculateUptime(validatorResult),
+		LastEpochInCommittee: validator.LastEpochInCommittee,
+	}
+
+	if err := profileKeys(&profile, information); err != nil {
+		return profile, err
+	}
+
+	for _, delegation := range validator.Delegations {
+		record := DelegationRecord{
+			ValidatorName:       validator.Name,
+			ValidatorAddress:    formatAddress(validator.Address),
+			ValidatorHexAddress: hexAddress(validator.Address),
+			DelegatorAddress:    formatAddress(delegation.DelegatorAddress),
+			DelegatorHexAddress: hexAddress(delegation.DelegatorAddress),
+			Amount:              utils.DecToFloat(delegation.Amount),
+			Reward:              utils.DecToFloat(delegation.Reward),
+		}
+
+		for _, undelegation := range delegation.Undelegations {
+			record.Undelegations = append(record.Undelegations, UndelegationRecord{
+				Amount: utils.DecToFloat(undelegation.Amount),
+				Epoch:  undelegation.Epoch,
+			})
+		}
+
+		profile.Delegations = append(profile.Delegations, record)
+	}
+
+	sort.SliceStable(profile.Delegations, func(i, j int) bool {
+		return profile.Delegations[i].Amount > profile.Delegations[j].Amount
+	})
+
+	// A failed balance lookup shouldn't prevent the rest of the profile from being shown
+	shardBalances, err := config.Configuration.Network.API.GetAllShardBalances(validator.Address)
+	if err != nil {
+		logger.WarningLog(fmt.Sprintf("Failed to look up the balance for validator wallet %s - error: %s", profile.Address, err.Error()))
+	} else {
+		totalBalance := numeric.ZeroDec()
+		profile.ShardBalances = make(map[uint32]float64)
+		for shardID, balance := range shardBalances {
+			totalBalance = totalBalance.Add(balance)
+			profile.ShardBalances[shardID] = utils.DecToFloat(balance)
+		}
+
+		balance := utils.DecToFloat(totalBalance)
+		profile.WalletBalance = &balance
+	}
+
+	profile.RewardsHistory, err = rewardsHistory(validator.Address)
+	if err != nil {
+		return profile, err
+	}
+
+	return profile, nil
+}
+
+// profileKeys - map the validator's keys to shards and flag the keys that are currently elected
+func profileKeys(profile *ValidatorProfile, information rpc.ValidatorInformation) error {
+	shardCount := config.Configuration.Network.API.ShardCount
+	if shardCount <= 0 {
+		return fmt.Errorf("failed to identify the shard count for network %s", config.Configuration.Network.Name)
+	}
+
+	elected := make(map[string]bool)
+	for _, electedKey := range information.ElectedKeys() {
+		elected[normalizeKey(electedKey.BLSPublicKey)] = true
+	}
+
+	profile.KeysPerShard = make([]int, shardCount)
+	profile.ElectedSlotsPerShard = make([]int, shardCount)
+
+	for _, blsKey := range information.Validator.BLSPublicKeys {
+		shardID, err := keyShard(blsKey, shardCount)
+		if err != nil {
+			return fmt.Errorf("failed to identify the shard of bls key %s - error: %s", blsKey, err.Error())
+		}
+
+		key := BLSKey{BLSPublicKey: blsKey, ShardID: shardID, Elected: elected[normalizeKey(blsKey)]}
+		profile.Keys = append(profile.Keys, key)
+
+		profile.KeysPerShard[shardID]++
+		if key.Elected {
+			profile.ElectedSlotsPerShard[shardID]++
+		}
+	}
+
+	return nil
+}
+
+// rewardsHistory - historical rewards aren't available using RPC, so the history is based on the stored snapshots
+func rewardsHistory(address string) ([]RewardsSnapshot, error) {
+	ids, err := ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	history := []RewardsSnapshot{}
+	for _, id := range ids {
+		// A single unreadable snapshot shouldn't prevent showing the validator, it's only missing from the history
+		rewardsSnapshot, found, err := loadRewardsSnapshot(id, address)
+		if err != nil {
+			logger.WarningLog(fmt.Sprintf("Skipping snapshot %s for the rewards history - error: %s", id, err.Error()))
+			continue
+		}
+
+		if found {
+			history = append(history, rewardsSnapshot)
+		}
+	}
+
+	return history, nil
+}
+
+func outputProfile(profile ValidatorProfile) {
+	logger.Title()
+
+	logger.InfoLog(fmt.Sprintf("Validator %s (%s)", profile.Name, profile.Address))
+	if profile.HexAddress != "" {
+		logger.Log(fmt.Sprintf("Hex address: %s", profile.HexAddress))
+	}
+	logger.Log(fmt.Sprintf("Identity: %s, website: %s, security contact: %s", profile.Identity, profile.Website, profile.SecurityContact))
+	if profile.Details != "" {
+		logger.Log(fmt.Sprintf("Details: %s", profile.Details))
+	}
+
+	statusMessage := fmt.Sprintf("Status: %s, elected: %t, EPoS status: %s, last epoch in committee: %d", profile.Status.Status, profile.Status.Elected, profile.Status.EPoSStatus, profile.LastEpochInCommittee)
+	if profile.Status.BootedStatus != "" {
+		statusMessage = fmt.Sprintf("%s, booted status: %s", statusMessage, profile.Status.BootedStatus)
+	}
+	if profile.Status.Status == "active" {
+		logger.SuccessLog(statusMessage)
+	} else {
+		logger.WarningLog(statusMessage)
+	}
+
+	logger.StakingLog(fmt.Sprintf("Self delegation: %s ONE, total delegation: %s ONE, delegations: %d", formatONE(profile.SelfDelegation), formatONE(profile.TotalDelegation), len(profile.Delegations)))
+	logger.StakingLog(fmt.Sprintf("Commission rate: %.2f%%, max rate: %.2f%%, max change rate: %.2f%%", profile.CommissionRate*100, profile.MaxRate*100, profile.MaxChangeRate*100))
+	logger.StakingLog(fmt.Sprintf("Lifetime rewards: %s ONE", formatONE(profile.LifetimeRewards)))
+
+	if profile.WalletBalance != nil {
+		shardIDs := []uint32{}
+		for shardID := range profile.ShardBalances {
+			shardIDs = append(shardIDs, shardID)
+		}
+		sort.Slice(shardIDs, func(i, j int) bool { return shardIDs[i] < shardIDs[j] })
+
+		shardBalances := []string{}
+		for _, shardID := range shardIDs {
+			shardBalances = append(shardBalances, fmt.Sprintf("shard %d: %s ONE", shardID, formatONE(profile.ShardBalances[shardID])))
+		}
+
+		logger.BalanceLog(fmt.Sprintf("Wallet balance: %s ONE (%s)", formatONE(*profile.WalletBalance), strings.Join(shardBalances, ", ")))
+	}
+
+	uptimeMessage := fmt.Sprintf("Current epoch uptime: %.2f%% (%d/%d), lifetime uptime: %.2f%% (%d/%d)", profile.Uptime.CurrentEpochUptime, profile.Uptime.CurrentEpochSigned, profile.Uptime.CurrentEpochToSign, profile.Uptime.LifetimeUptime, profile.Uptime.LifetimeSigned, profile.Uptime.LifetimeToSign)
+	if profile.Uptime.BelowThreshold {
+		logger.WarningLog(uptimeMessage)
+	} else {
+		logger.Log(uptimeMessage)
+	}
+
+	logger.Log(fmt.Sprintf("BLS keys: %d, keys per shard: %v, elected slots per shard: %v", len(profile.Keys), profile.KeysPerShard, profile.ElectedSlotsPerShard))
+	for _, key := range profile.Keys {
+		logger.Log(fmt.Sprintf("BLS key %s - shard: %d, elected: %t", key.BLSPublicKey, key.ShardID, key.Elected))
+	}
+
+	limit := config.ValidatorArgs.Show.Limit
+	if limit <= 0 || limit > len(profile.Delegations) {
+		limit = len(profile.Delegations)
+	}
+	for index, delegation := range profile.Delegations[:limit] {
+		logger.StakingLog(fmt.Sprintf("#%d delegator %s - delegated: %s ONE, rewards: %s ONE, pending undelegations: %d", index+1, delegation.DelegatorAddress, formatONE(delegation.Amount), formatONE(delegation.Reward), len(delegation.Undelegations)))
+	}
+
+	if len(profile.RewardsHistory) == 0 {
+		logger.Log("No snapshots containing the validator were found - run `validators analyze` periodically to build up a rewards history")
+	}
+	for _, rewards := range profile.RewardsHistory {
+		logger.Log(fmt.Sprintf("Snapshot %s - lifetime rewards: %s ONE, total delegation: %s ONE", rewards.Snapshot, formatONE(rewards.LifetimeRewards), formatONE(rewards.TotalDelegation)))
+	}
+}
+
+// generateProfileDashboard - render the validator's charts and combine them into a one page png or html dashboard
+func generateProfileDashboard(profile ValidatorProfile, address string, format string) (string, error) {
+	if format != "png" && format != "html" {
+		return "", fmt.Errorf("invalid dashboard format %s - valid options: png or html", format)
+	}
+
+	prefix := fmt.Sprintf("validators/%s-%s", strings.ToLower(config.Configuration.Network.Name), address)
+	panels := []string{}
+
+	uptimeFileName := fmt.Sprintf("%s-uptime.png", prefix)
+	if err := chartProfileUptime(uptimeFileName, profile); err != nil {
+		return "", err
+	}
+	panels = append(panels, uptimeFileName)
+
+	if len(profile.Keys) > 0 {
+		slotsFileName := fmt.Sprintf("%s-slots.png", prefix)
+		if err := chartProfileSlots(slotsFileName, profile); err != nil {
+			return "", err
+		}
+		panels = append(panels, slotsFileName)
+	}
+
+	if len(profile.Delegations) > 0 {
+		delegationsFileName := fmt.Sprintf("%s-delegations.png", prefix)
+		if err := chartProfileDelegations(delegationsFileName, profile); err != nil {
+			return "", err
+		}
+		panels = append(panels, delegationsFileName)
+	}
+
+	if len(profile.RewardsHistory) >= 2 {
+		rewardsFileName := fmt.Sprintf("%s-rewards.png", prefix)
+		if err := chartProfileRewards(rewardsFileName, profile); err != nil {
+			return "", err
+		}
+		panels = append(panels, rewardsFileName)
+	}
+
+	title := fmt.Sprintf("Open Staking Validator - %s", profile.Name)
+	details := []string{
+		fmt.Sprintf("Address: %s", profile.Address),
+		fmt.Sprintf("Status: %s, elected: %t, EPoS status: %s", profile.Status.Status, profile.Status.Elected, profile.Status.EPoSStatus),
+		fmt.Sprintf("Total delegation: %s ONE, self delegation: %s ONE, delegations: %d", formatONE(profile.TotalDelegation), formatONE(profile.SelfDelegation), len(profile.Delegations)),
+		fmt.Sprintf("Lifetime rewards: %s ONE, commission rate: %.2f%%", formatONE(profile.LifetimeRewards), profile.CommissionRate*100),
+	}
+
+	fileName := fmt.Sprintf("%s-dashboard.%s", prefix, format)
+
+	var err error
+	if format == "html" {
+		err = charts.GenerateDashboardHTML(fileName, title, details, panels)
+	} else {
+		err = charts.GenerateDashboardPNG(fileName, title, details, panels)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func chartProfileUptime(fileName string, profile ValidatorProfile) error {
+	bars := []chart.Value{
+		{Label: "Current Epoch", Value: profile.Uptime.CurrentEpochUptime},
+		{Label: "Lifetime", Value: profile.Uptime.LifetimeUptime},
+	}
+
+	if profile.Uptime.BelowThreshold {
+		bars[0].Style = charts.AlertBarStyle()
+	}
+
+	return charts.GenerateBarChart(fileName, "Uptime", "Uptime", func(v interface{}) string {
+		return formatPercentage(v.(float64))
+	}, bars)
+}
+
+func chartProfileSlots(fileName string, profile ValidatorProfile) error {
+	bars := []chart.StackedBar{}
+	for shardID, keyCount := range profile.KeysPerShard {
+		// Stacked bars are relative to their total, so shards without any keys can't be rendered
+		if keyCount == 0 {
+			continue
+		}
+
+		bar := chart.StackedBar{Name: fmt.Sprintf("Shard %d", shardID)}
+
+		notElected := keyCount - profile.ElectedSlotsPerShard[shardID]
+		if profile.ElectedSlotsPerShard[shardID] > 0 {
+			bar.Values = append(bar.Values, chart.Value{Label: fmt.Sprintf("Elected: %d", profile.ElectedSlotsPerShard[shardID]), Value: float64(profile.ElectedSlotsPerShard[shardID])})
+		}
+		if notElected > 0 {
+			bar.Values = append(bar.Values, chart.Value{Label: fmt.Sprintf("Not elected: %d", notElected), Value: float64(notElected)})
+		}
+
+		bars = append(bars, bar)
+	}
+
+	return charts.GenerateStackedBarChart(fileName, "BLS Keys & Elected Slots per Shard", bars)
+}
+
+func chartProfileDelegations(fileName string, profile ValidatorProfile) error {
+	limit := config.ValidatorArgs.Show.Limit
+	if limit <= 0 || limit > len(profile.Delegations) {
+		limit = len(profile.Delegations)
+	}
+
+	bars := []chart.Value{}
+	for _, delegation := range profile.Delegations[:limit] {
+		bars = append(bars, chart.Value{
+			Label: formatForLabel(delegation.DelegatorAddress),
+			Value: delegation.Amount,
+		})
+	}
+
+	return charts.GenerateBarChart(fileName, "Largest Delegations", "Delegation", func(v interface{}) string {
+		return formatONE(v.(float64))
+	}, bars)
+}
+
+func chartProfileRewards(fileName string, profile ValidatorProfile) error {
+	xValues := []time.Time{}
+	rewards := []float64{}
+	delegations := []float64{}
+	for _, snapshot := range profile.RewardsHistory {
+		xValues = append(xValues, snapshot.Time)
+		rewards = append(rewards, snapshot.LifetimeRewards)
+		delegations = append(delegations, snapshot.TotalDelegation)
+	}
+
+	series := []charts.Series{
+		{Title: "Lifetime Rewards", YValues: rewards},
+		{Title: "Total Delegation", YValues: delegations, Secondary: true},
+	}
+
+	return charts.GenerateMultiTimeSeriesChart(fileName, "Snapshot", "Rewards (ONE)", "Delegation (ONE)", xValues, series, []string{})
+}
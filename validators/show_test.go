@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+)
+
+func TestRewardsHistorySkipsUnreadableSnapshots(t *testing.T) {
+	defer func(path string, network string) {
+		config.Configuration.Export.Path = path
+		config.Configuration.Network.Name = network
+	}(config.Configuration.Export.Path, config.Configuration.Network.Name)
+
+	exportPath, err := ioutil.TempDir("", "snapshots")
+	if err != nil {
+		t.Fatalf("failed to create a temporary export path - error: %s", err.Error())
+	}
+	defer os.RemoveAll(exportPath)
+
+	config.Configuration.Export.Path = exportPath
+	config.Configuration.Network.Name = "testnet"
+	config.ConfigureStylingConfig()
+
+	oneONE := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+	result := sdkValidator.RPCValidatorResult{RawTotalDelegation: new(big.Int).Mul(big.NewInt(1000), oneONE)}
+	result.Validator.Address = "one1validator"
+	result.Lifetime.RawRewardAccumulated = new(big.Int).Mul(big.NewInt(25), oneONE)
+
+	other := sdkValidator.RPCValidatorResult{}
+	other.Validator.Address = "one1other"
+
+	if _, err = SaveSnapshot([]ValidatorResult{{Result: other}, {Result: result}}); err != nil {
+		t.Fatalf("failed to save a snapshot - error: %s", err.Error())
+	}
+
+	directory := filepath.Join(exportPath, snapshotDirectory())
+	if err = ioutil.WriteFile(filepath.Join(directory, "0000-corrupt.json"), []byte("{\"validators\": ["), 0644); err != nil {
+		t.Fatalf("failed to write a corrupt snapshot - error: %s", err.Error())
+	}
+
+	history, err := rewardsHistory("one1validator")
+	if err != nil {
+		t.Fatalf("rewardsHistory returned an unexpected error: %s", err.Error())
+	}
+
+	if len(history) != 1 {
+		t.Fatalf("rewardsHistory returned %d snapshot(s), expected 1", len(history))
+	}
+
+	if history[0].LifetimeRewards != 25 || history[0].TotalDelegation != 1000 {
+		t.Errorf("rewardsHistory = %+v, expected lifetime rewards of 25 and a total delegation of 1000", history[0])
+	}
+
+	history, err = rewardsHistory("one1unknown")
+	if err != nil || len(history) != 0 {
+		t.Errorf("rewardsHistory for an unknown validator = %v, %v, expected an empty history", history, err)
+	}
+}
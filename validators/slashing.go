@@ -0,0 +1,161 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+	"github.com/wcharczuk/go-chart"
+)
+
+// BannedValidator - a validator that has been banned from the network for double signing
+type BannedValidator struct {
+	Name                 string  `json:"name"`
+	Address              string  `json:"address"`
+	HexAddress           string  `json:"hex-address,omitempty"`
+	Status               string  `json:"status"`
+	LastEpochInCommittee uint32  `json:"last-epoch-in-committee"`
+	SelfDelegation       float64 `json:"self-delegation"`
+	TotalDelegation      float64 `json:"total-delegation"`
+}
+
+// Slashing - report validators that have been slashed and banned for double signing
+// The validator information RPC of the currently supported protocol version doesn't expose the individual slash records
+// (epoch, reporter, amount slashed and victims), so the report is limited to the validators flagged as banned, charted by the last epoch they were in the committee
+func Slashing() error {
+	fmt.Printf("Will report slashed validators - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	validatorResults, err := Filtered()
+	if err != nil {
+		return err
+	}
+
+	banned := []BannedValidator{}
+	totalSelfDelegation, totalDelegation := 0.0, 0.0
+	for _, validatorResult := range validatorResults {
+		if !isBanned(validatorResult) {
+			continue
+		}
+
+		bannedValidator := BannedValidator{
+			Name:                 validatorResult.Validator.Name,
+			Address:              formatAddress(validatorResult.Validator.Address),
+			HexAddress:           hexAddress(validatorResult.Validator.Address),
+			Status:               validatorResult.EposStatus,
+			LastEpochInCommittee: validatorResult.Validator.LastEpochInCommittee,
+			SelfDelegation:       utils.DecToFloat(selfDelegation(validatorResult.Validator).Amount),
+			TotalDelegation:      utils.DecToFloat(validatorResult.TotalDelegation),
+		}
+
+		totalSelfDelegation += bannedValidator.SelfDelegation
+		totalDelegation += bannedValidator.TotalDelegation
+
+		banned = append(banned, bannedValidator)
+	}
+
+	fmt.Println("Note: individual slash records (epoch, reporter, amount slashed, victims) aren't exposed by the validator information RPC, only validators banned for double signing can be reported")
+
+	if len(banned) == 0 {
+		fmt.Printf("None of the %d matching validators have been banned for double signing\n", len(validatorResults))
+		return nil
+	}
+
+	for _, bannedValidator := range banned {
+		fmt.Printf("Banned validator %s (%s) - last epoch in committee: %d, remaining self delegation: %.2f ONE, remaining total delegation: %.2f ONE\n", bannedValidator.Name, bannedValidator.Address, bannedValidator.LastEpochInCommittee, bannedValidator.SelfDelegation, bannedValidator.TotalDelegation)
+	}
+
+	fmt.Printf("Found a total of %d banned validators - remaining self delegation: %.2f ONE, remaining total delegation: %.2f ONE\n", len(banned), totalSelfDelegation, totalDelegation)
+
+	if err = chartBans(banned); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportBannedToCSV(banned)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported banned validators to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/slashing-%s-UTC.json", utils.FormattedTimeString(time.Now().UTC())), banned)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported banned validators to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// isBanned - double signing results in the validator being permanently banned, which is reflected in the EPoS status
+func isBanned(validatorResult sdkValidator.RPCValidatorResult) bool {
+	return strings.Contains(strings.ToLower(validatorResult.EposStatus), "banned") || strings.Contains(strings.ToLower(validatorResult.Validator.EligibilityStatus), "banned")
+}
+
+// chartBans - chart the number of banned validators per epoch
+// Banned validators are removed from the committee right away, so the last epoch they were in the committee approximates the epoch they were slashed in
+func chartBans(banned []BannedValidator) error {
+	bansPerEpoch := make(map[uint32]int)
+	epochs := []uint32{}
+	for _, bannedValidator := range banned {
+		if _, exists := bansPerEpoch[bannedValidator.LastEpochInCommittee]; !exists {
+			epochs = append(epochs, bannedValidator.LastEpochInCommittee)
+		}
+		bansPerEpoch[bannedValidator.LastEpochInCommittee]++
+	}
+
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	bars := []chart.Value{}
+	for _, epoch := range epochs {
+		bars = append(bars, chart.Value{
+			Label: fmt.Sprintf("Epoch %d", epoch),
+			Value: float64(bansPerEpoch[epoch]),
+		})
+	}
+
+	fileName := fmt.Sprintf("validators/%s-slashing.png", strings.ToLower(config.Configuration.Network.Name))
+
+	return charts.GenerateBarChart(fileName, "Open Staking Banned Validators per Epoch", "Banned Validators", func(v interface{}) string {
+		return formatCount(v.(float64))
+	}, bars)
+}
+
+func exportBannedToCSV(banned []BannedValidator) (string, error) {
+	fileName := fmt.Sprintf("validators/slashing-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	headers := []string{"Name"}
+	headers = append(headers, addressHeaders("Address")...)
+	headers = append(headers, "Status", "Last Epoch In Committee", "Self Delegation", "Total Delegation")
+
+	rows := [][]string{headers}
+
+	for _, bannedValidator := range banned {
+		row := []string{bannedValidator.Name}
+		row = append(row, addressColumns(bannedValidator.Address, bannedValidator.HexAddress)...)
+		row = append(row,
+			bannedValidator.Status,
+			fmt.Sprintf("%d", bannedValidator.LastEpochInCommittee),
+			fmt.Sprintf("%f", bannedValidator.SelfDelegation),
+			fmt.Sprintf("%f", bannedValidator.TotalDelegation),
+		)
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
@@ -0,0 +1,260 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// Snapshot - a persisted point-in-time set of analyzed validators
+type Snapshot struct {
+	ID         string              `json:"-"`
+	Time       time.Time           `json:"time"`
+	Network    string              `json:"network"`
+	Validators []SnapshotValidator `json:"validators"`
+}
+
+// SnapshotValidator - the persisted representation of a ValidatorResult
+// Only the raw RPC values are persisted - converted values are recalculated when a snapshot is loaded
+type SnapshotValidator struct {
+	Result        sdkValidator.RPCValidatorResult `json:"result"`
+	Status        *ValidatorStatus                `json:"status,omitempty"`
+	Balance       string                          `json:"balance,omitempty"`
+	ShardBalances map[uint32]string               `json:"shard-balances,omitempty"`
+	Error         string                          `json:"error,omitempty"`
+}
+
+// validatorRewardsSnapshot - the subset of a snapshot needed to trace the rewards of a validator over time
+// Only these fields are decoded, so loading every stored snapshot doesn't have to decode and convert all delegations
+type validatorRewardsSnapshot struct {
+	Time       time.Time `json:"time"`
+	Validators []struct {
+		Result struct {
+			Validator struct {
+				Address string `json:"address"`
+			} `json:"validator"`
+			RawTotalDelegation *big.Int `json:"total-delegation"`
+			Lifetime           struct {
+				RawRewardAccumulated *big.Int `json:"reward-accumulated"`
+			} `json:"lifetime"`
+		} `json:"result"`
+	} `json:"validators"`
+}
+
+// SaveSnapshot - persist the results of a validator analysis run to the snapshot store
+// The results should cover all validators, a filtered set would show up as removed validators when diffing snapshots
+func SaveSnapshot(validatorResults []ValidatorResult) (string, error) {
+	snapshot := Snapshot{
+		Time:    time.Now().UTC(),
+		Network: config.Configuration.Network.Name,
+	}
+
+	for _, validatorResult := range validatorResults {
+		status := validatorResult.Status
+		snapshotValidator := SnapshotValidator{Result: validatorResult.Result, Status: &status}
+
+		if !validatorResult.Balance.IsNil() {
+			snapshotValidator.Balance = validatorResult.Balance.String()
+		}
+
+		if len(validatorResult.ShardBalances) > 0 {
+			snapshotValidator.ShardBalances = make(map[uint32]string)
+			for shardID, balance := range validatorResult.ShardBalances {
+				snapshotValidator.ShardBalances[shardID] = balance.String()
+			}
+		}
+
+		if validatorResult.Error != nil {
+			snapshotValidator.Error = validatorResult.Error.Error()
+		}
+
+		snapshot.Validators = append(snapshot.Validators, snapshotValidator)
+	}
+
+	// Nanoseconds are included so that multiple runs within the same second don't overwrite each other (ids still sort chronologically)
+	fileName := filepath.Join(snapshotDirectory(), fmt.Sprintf("%s-%09d.json", utils.FormattedTimeString(snapshot.Time), snapshot.Time.Nanosecond()))
+
+	return export.ExportJSON(fileName, snapshot)
+}
+
+// ListSnapshots - list the ids of all stored snapshots for the current network, oldest first
+func ListSnapshots() ([]string, error) {
+	files, err := ioutil.ReadDir(filepath.Join(config.Configuration.Export.Path, snapshotDirectory()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
+			ids = append(ids, strings.TrimSuffix(file.Name(), ".json"))
+		}
+	}
+
+	// Snapshot ids are formatted timestamps and thus sort chronologically
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// LoadSnapshot - load a snapshot using its id, a file path or one of the aliases "latest" and "previous"
+func LoadSnapshot(identifier string) (Snapshot, error) {
+	snapshot := Snapshot{}
+
+	path, err := resolveSnapshot(identifier)
+	if err != nil {
+		return snapshot, err
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return snapshot, err
+	}
+
+	if err = json.Unmarshal(bytes, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("failed to parse snapshot %s - error: %s", path, err.Error())
+	}
+
+	snapshot.ID = strings.TrimSuffix(filepath.Base(path), ".json")
+
+	for index := range snapshot.Validators {
+		if err = snapshot.Validators[index].Result.Initialize(); err != nil {
+			return snapshot, fmt.Errorf("failed to initialize validator data in snapshot %s - error: %s", path, err.Error())
+		}
+	}
+
+	return snapshot, nil
+}
+
+// loadRewardsSnapshot - load the rewards and total delegation of a given validator from a snapshot, found is false if the validator isn't part of it
+func loadRewardsSnapshot(id string, address string) (rewardsSnapshot RewardsSnapshot, found bool, err error) {
+	path, err := resolveSnapshot(id)
+	if err != nil {
+		return rewardsSnapshot, false, err
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return rewardsSnapshot, false, err
+	}
+
+	snapshot := validatorRewardsSnapshot{}
+	if err = json.Unmarshal(bytes, &snapshot); err != nil {
+		return rewardsSnapshot, false, fmt.Errorf("failed to parse snapshot %s - error: %s", path, err.Error())
+	}
+
+	for _, snapshotValidator := range snapshot.Validators {
+		if snapshotValidator.Result.Validator.Address != address {
+			continue
+		}
+
+		// Reuse go-lib's conversion of the raw atto amounts
+		result := sdkValidator.RPCValidatorResult{RawTotalDelegation: snapshotValidator.Result.RawTotalDelegation}
+		result.Lifetime.RawRewardAccumulated = snapshotValidator.Result.Lifetime.RawRewardAccumulated
+		if err = result.Initialize(); err != nil {
+			return rewardsSnapshot, false, fmt.Errorf("failed to initialize validator data in snapshot %s - error: %s", path, err.Error())
+		}
+
+		return RewardsSnapshot{
+			Time:            snapshot.Time,
+			Snapshot:        id,
+			LifetimeRewards: utils.DecToFloat(result.Lifetime.RewardAccumulated),
+			TotalDelegation: utils.DecToFloat(result.TotalDelegation),
+		}, true, nil
+	}
+
+	return rewardsSnapshot, false, nil
+}
+
+// ValidatorResults - convert the persisted validators back to ValidatorResults
+func (snapshot Snapshot) ValidatorResults() []ValidatorResult {
+	validatorResults := []ValidatorResult{}
+
+	for _, snapshotValidator := range snapshot.Validators {
+		validatorResult := ValidatorResult{
+			Result: snapshotValidator.Result,
+			Uptime: CalculateUptime(snapshotValidator.Result),
+		}
+
+		// Snapshots created before statuses were persisted lack the booted status
+		if snapshotValidator.Status != nil {
+			validatorResult.Status = *snapshotValidator.Status
+		} else {
+			validatorResult.Status = DetermineStatus(snapshotValidator.Result, "")
+		}
+
+		if snapshotValidator.Balance != "" {
+			if balance, err := numeric.NewDecFromStr(snapshotValidator.Balance); err == nil {
+				validatorResult.Balance = balance
+			}
+		}
+
+		for shardID, shardBalance := range snapshotValidator.ShardBalances {
+			if balance, err := numeric.NewDecFromStr(shardBalance); err == nil {
+				if validatorResult.ShardBalances == nil {
+					validatorResult.ShardBalances = make(map[uint32]numeric.Dec)
+				}
+				validatorResult.ShardBalances[shardID] = balance
+			}
+		}
+
+		if snapshotValidator.Error != "" {
+			validatorResult.Error = fmt.Errorf("%s", snapshotValidator.Error)
+		}
+
+		validatorResults = append(validatorResults, validatorResult)
+	}
+
+	return validatorResults
+}
+
+func resolveSnapshot(identifier string) (string, error) {
+	if _, err := os.Stat(identifier); err == nil {
+		return identifier, nil
+	}
+
+	ids, err := ListSnapshots()
+	if err != nil {
+		return "", err
+	}
+
+	id := identifier
+	switch strings.ToLower(identifier) {
+	case "latest":
+		if len(ids) < 1 {
+			return "", fmt.Errorf("no snapshots found for network %s - run `validators analyze` to create one", config.Configuration.Network.Name)
+		}
+		id = ids[len(ids)-1]
+	case "previous":
+		if len(ids) < 2 {
+			return "", fmt.Errorf("at least two snapshots are required for network %s to use \"previous\"", config.Configuration.Network.Name)
+		}
+		id = ids[len(ids)-2]
+	}
+
+	path := filepath.Join(config.Configuration.Export.Path, snapshotDirectory(), fmt.Sprintf("%s.json", id))
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("unknown snapshot %q - available snapshots: %s", identifier, strings.Join(ids, ", "))
+	}
+
+	return path, nil
+}
+
+func snapshotDirectory() string {
+	return filepath.Join("snapshots", strings.ToLower(config.Configuration.Network.Name))
+}
@@ -0,0 +1,205 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+)
+
+// ValidatorStatus - the full status of a validator
+type ValidatorStatus struct {
+	Status            string `json:"status"`
+	Elected           bool   `json:"elected"`
+	EPoSStatus        string `json:"epos-status"`
+	EligibilityStatus string `json:"eligibility-status,omitempty"`
+	BootedStatus      string `json:"booted-status,omitempty"`
+}
+
+// StatusTransition - a change in status for a given validator between two snapshots
+type StatusTransition struct {
+	Name       string          `json:"name"`
+	Address    string          `json:"address"`
+	HexAddress string          `json:"hex-address,omitempty"`
+	From       ValidatorStatus `json:"from"`
+	To         ValidatorStatus `json:"to"`
+	DroppedOut bool            `json:"dropped-out"`
+	Reason     string          `json:"reason,omitempty"`
+}
+
+// DetermineStatus - determine the status of a validator, the booted status isn't decoded by go-lib and has to be passed separately
+func DetermineStatus(validatorResult sdkValidator.RPCValidatorResult, bootedStatus string) ValidatorStatus {
+	status := ValidatorStatus{
+		Status:            "active",
+		Elected:           validatorResult.CurrentlyInCommittee,
+		EPoSStatus:        validatorResult.EposStatus,
+		EligibilityStatus: validatorResult.Validator.EligibilityStatus,
+		BootedStatus:      bootedStatus,
+	}
+
+	switch {
+	case isBanned(validatorResult):
+		status.Status = "banned"
+	case strings.EqualFold(status.EligibilityStatus, "inactive"), strings.HasPrefix(strings.ToLower(status.EPoSStatus), "not eligible"):
+		status.Status = "inactive"
+	}
+
+	return status
+}
+
+// StatusTransitions - track validator status transitions between two snapshots
+func StatusTransitions() error {
+	from, err := LoadSnapshot(config.ValidatorArgs.Status.From)
+	if err != nil {
+		return err
+	}
+
+	to, err := LoadSnapshot(config.ValidatorArgs.Status.To)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Comparing validator statuses in snapshot %s (%s) to snapshot %s (%s)\n", from.ID, from.Time.Format(time.RFC3339), to.ID, to.Time.Format(time.RFC3339))
+
+	transitions := statusTransitions(from, to)
+
+	droppedOut := 0
+	for _, transition := range transitions {
+		if transition.DroppedOut {
+			droppedOut++
+			fmt.Printf("Validator %s (%s) dropped out of the committee - reason: %s\n", transition.Name, transition.Address, transition.Reason)
+		} else {
+			fmt.Printf("Validator %s (%s) - status: %s -> %s, elected: %t -> %t\n", transition.Name, transition.Address, transition.From.Status, transition.To.Status, transition.From.Elected, transition.To.Elected)
+		}
+	}
+
+	fmt.Printf("Status transitions: %d, validators that dropped out of the committee: %d\n", len(transitions), droppedOut)
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportTransitionsToCSV(from.ID, to.ID, transitions)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported the status transitions to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/status-%s-%s.json", from.ID, to.ID), transitions)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported the status transitions to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+func statusTransitions(from Snapshot, to Snapshot) []StatusTransition {
+	previous := make(map[string]ValidatorStatus)
+	for _, validatorResult := range from.ValidatorResults() {
+		previous[validatorResult.Result.Validator.Address] = validatorResult.Status
+	}
+
+	transitions := []StatusTransition{}
+	for _, validatorResult := range to.ValidatorResults() {
+		previousStatus, exists := previous[validatorResult.Result.Validator.Address]
+		if !exists {
+			continue
+		}
+
+		currentStatus := validatorResult.Status
+		if previousStatus.Status == currentStatus.Status && previousStatus.Elected == currentStatus.Elected && previousStatus.BootedStatus == currentStatus.BootedStatus {
+			continue
+		}
+
+		transition := StatusTransition{
+			Name:       validatorResult.Result.Validator.Name,
+			Address:    formatAddress(validatorResult.Result.Validator.Address),
+			HexAddress: hexAddress(validatorResult.Result.Validator.Address),
+			From:       previousStatus,
+			To:         currentStatus,
+			DroppedOut: previousStatus.Elected && !currentStatus.Elected,
+		}
+
+		if transition.DroppedOut {
+			transition.Reason = dropOutReason(currentStatus)
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	sort.SliceStable(transitions, func(i, j int) bool {
+		if transitions[i].DroppedOut != transitions[j].DroppedOut {
+			return transitions[i].DroppedOut
+		}
+		return transitions[i].Address < transitions[j].Address
+	})
+
+	return transitions
+}
+
+// dropOutReason - prefer the protocol's booted status and fall back to the validator's status
+func dropOutReason(status ValidatorStatus) string {
+	if status.BootedStatus != "" && status.BootedStatus != "not booted" {
+		return status.BootedStatus
+	}
+
+	switch status.Status {
+	case "banned":
+		return "banned for double signing"
+	case "inactive":
+		return "turned inactive"
+	default:
+		return fmt.Sprintf("unknown (%s)", status.EPoSStatus)
+	}
+}
+
+func exportTransitionsToCSV(fromID string, toID string, transitions []StatusTransition) (string, error) {
+	fileName := fmt.Sprintf("validators/status-%s-%s.csv", fromID, toID)
+
+	headers := []string{"Name"}
+	headers = append(headers, addressHeaders("Address")...)
+	headers = append(headers,
+		"Previous Status",
+		"Current Status",
+		"Previously Elected",
+		"Currently Elected",
+		"Previous EPoS Status",
+		"Current EPoS Status",
+		"Current Booted Status",
+		"Dropped Out",
+		"Reason",
+	)
+
+	rows := [][]string{headers}
+
+	for _, transition := range transitions {
+		row := []string{transition.Name}
+		row = append(row, addressColumns(transition.Address, transition.HexAddress)...)
+		row = append(row,
+			transition.From.Status,
+			transition.To.Status,
+			fmt.Sprintf("%t", transition.From.Elected),
+			fmt.Sprintf("%t", transition.To.Elected),
+			transition.From.EPoSStatus,
+			transition.To.EPoSStatus,
+			transition.To.BootedStatus,
+			fmt.Sprintf("%t", transition.DroppedOut),
+			transition.Reason,
+		)
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
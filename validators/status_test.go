@@ -0,0 +1,88 @@
+package validators
+
+import (
+	"testing"
+
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+)
+
+func testSnapshotValidator(address string, status ValidatorStatus) SnapshotValidator {
+	validatorResult := sdkValidator.RPCValidatorResult{}
+	validatorResult.Validator.Name = "Validator " + address
+	validatorResult.Validator.Address = address
+
+	return SnapshotValidator{Result: validatorResult, Status: &status}
+}
+
+func TestStatusTransitions(t *testing.T) {
+	elected := ValidatorStatus{Status: "active", Elected: true, EPoSStatus: "currently elected"}
+	eligible := ValidatorStatus{Status: "active", EPoSStatus: "eligible to be elected next epoch"}
+	booted := ValidatorStatus{Status: "active", EPoSStatus: "eligible to be elected next epoch", BootedStatus: "insufficient uptime during an epoch"}
+	banned := ValidatorStatus{Status: "banned", EPoSStatus: "banned forever"}
+	inactive := ValidatorStatus{Status: "inactive", EPoSStatus: "not eligible to be elected next epoch"}
+
+	from := Snapshot{Validators: []SnapshotValidator{
+		testSnapshotValidator("one1unchanged", elected),
+		testSnapshotValidator("one1booted", elected),
+		testSnapshotValidator("one1banned", elected),
+		testSnapshotValidator("one1inactive", elected),
+		testSnapshotValidator("one1unknown", elected),
+		testSnapshotValidator("one1elected", eligible),
+		testSnapshotValidator("one1removed", elected),
+	}}
+
+	to := Snapshot{Validators: []SnapshotValidator{
+		testSnapshotValidator("one1unchanged", elected),
+		testSnapshotValidator("one1booted", booted),
+		testSnapshotValidator("one1banned", banned),
+		testSnapshotValidator("one1inactive", inactive),
+		testSnapshotValidator("one1unknown", eligible),
+		testSnapshotValidator("one1elected", elected),
+		testSnapshotValidator("one1added", eligible),
+	}}
+
+	expected := []struct {
+		address    string
+		droppedOut bool
+		reason     string
+	}{
+		// Validators that dropped out are listed first, sorted by address
+		{"one1banned", true, "banned for double signing"},
+		{"one1booted", true, "insufficient uptime during an epoch"},
+		{"one1inactive", true, "turned inactive"},
+		{"one1unknown", true, "unknown (eligible to be elected next epoch)"},
+		{"one1elected", false, ""},
+	}
+
+	transitions := statusTransitions(from, to)
+	if len(transitions) != len(expected) {
+		t.Fatalf("statusTransitions returned %d transitions, expected %d: %+v", len(transitions), len(expected), transitions)
+	}
+
+	for index, transition := range transitions {
+		if transition.Address != expected[index].address || transition.DroppedOut != expected[index].droppedOut || transition.Reason != expected[index].reason {
+			t.Errorf("transition %d = %s (dropped out: %t, reason: %q), expected %s (dropped out: %t, reason: %q)", index, transition.Address, transition.DroppedOut, transition.Reason, expected[index].address, expected[index].droppedOut, expected[index].reason)
+		}
+	}
+}
+
+func TestStatusTransitionsWithoutPersistedStatus(t *testing.T) {
+	// Snapshots created before statuses were persisted fall back to the status determined from the validator information
+	previous := sdkValidator.RPCValidatorResult{CurrentlyInCommittee: true, EposStatus: "currently elected"}
+	previous.Validator.Address = "one1legacy"
+	current := previous
+	current.CurrentlyInCommittee = false
+	current.EposStatus = "banned forever"
+
+	from := Snapshot{Validators: []SnapshotValidator{{Result: previous}}}
+	to := Snapshot{Validators: []SnapshotValidator{{Result: current}}}
+
+	transitions := statusTransitions(from, to)
+	if len(transitions) != 1 {
+		t.Fatalf("statusTransitions returned %d transitions, expected 1", len(transitions))
+	}
+
+	if !transitions[0].DroppedOut || transitions[0].Reason != "banned for double signing" {
+		t.Errorf("transition = %+v, expected a drop out due to a ban", transitions[0])
+	}
+}
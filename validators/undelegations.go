@@ -0,0 +1,355 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/export"
+	"github.com/SebastianJ/harmony-stats/rpc"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkRPC "github.com/harmony-one/go-lib/rpc"
+	stakingTypes "github.com/harmony-one/harmony/staking/types"
+)
+
+// PendingUndelegation - a pending undelegation and its expected release
+type PendingUndelegation struct {
+	ValidatorName       string    `json:"validator-name"`
+	ValidatorAddress    string    `json:"validator-address"`
+	ValidatorHexAddress string    `json:"validator-hex-address,omitempty"`
+	DelegatorAddress    string    `json:"delegator-address"`
+	DelegatorHexAddress string    `json:"delegator-hex-address,omitempty"`
+	Amount              float64   `json:"amount"`
+	UndelegationEpoch   uint64    `json:"undelegation-epoch"`
+	ReleaseEpoch        uint64    `json:"release-epoch"`
+	EstimatedRelease    time.Time `json:"estimated-release"`
+}
+
+// ValidatorUndelegations - the total amount undelegating from a given validator
+type ValidatorUndelegations struct {
+	Name          string  `json:"name"`
+	Address       string  `json:"address"`
+	HexAddress    string  `json:"hex-address,omitempty"`
+	Amount        float64 `json:"amount"`
+	Undelegations int     `json:"undelegations"`
+}
+
+// UnlockEpoch - the amount of tokens expected to be released at the start of a given epoch
+type UnlockEpoch struct {
+	Epoch            uint64    `json:"epoch"`
+	EstimatedRelease time.Time `json:"estimated-release"`
+	Amount           float64   `json:"amount"`
+	Cumulative       float64   `json:"cumulative"`
+}
+
+// UndelegationReport - all pending undelegations, aggregated per validator and per release epoch
+type UndelegationReport struct {
+	Time          time.Time                `json:"time"`
+	CurrentEpoch  uint64                   `json:"current-epoch"`
+	EpochDuration string                   `json:"epoch-duration"`
+	TotalAmount   float64                  `json:"total-amount"`
+	Validators    []ValidatorUndelegations `json:"validators"`
+	Schedule      []UnlockEpoch            `json:"schedule"`
+	Undelegations []PendingUndelegation    `json:"undelegations"`
+}
+
+// Undelegations - report the tokens currently undelegating and when they're expected to be released
+// Undelegated tokens are released LockPeriodInEpoch epochs after the undelegation, or LockPeriodInEpoch epochs after
+// the validator was last in the committee if that's earlier (see releaseEpoch)
+func Undelegations() error {
+	fmt.Printf("Will analyze pending undelegations - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	currentEpoch, epochStart, epochDuration, err := epochTiming()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Current epoch: %d, estimated epoch duration: %s\n", currentEpoch, epochDuration)
+
+	validatorResults, err := Filtered()
+	if err != nil {
+		return err
+	}
+
+	report := UndelegationReport{
+		Time:          time.Now().UTC(),
+		CurrentEpoch:  currentEpoch,
+		EpochDuration: epochDuration.String(),
+	}
+
+	scheduleMapping := make(map[uint64]float64)
+	for _, validatorResult := range validatorResults {
+		validator := validatorResult.Validator
+		validatorUndelegations := ValidatorUndelegations{Name: validator.Name, Address: formatAddress(validator.Address), HexAddress: hexAddress(validator.Address)}
+
+		for _, delegation := range validator.Delegations {
+			for _, undelegation := range delegation.Undelegations {
+				releaseEpoch := releaseEpoch(uint64(undelegation.Epoch), uint64(validator.LastEpochInCommittee), currentEpoch)
+
+				pending := PendingUndelegation{
+					ValidatorName:       validator.Name,
+					ValidatorAddress:    formatAddress(validator.Address),
+					ValidatorHexAddress: hexAddress(validator.Address),
+					DelegatorAddress:    formatAddress(delegation.DelegatorAddress),
+					DelegatorHexAddress: hexAddress(delegation.DelegatorAddress),
+					Amount:              utils.DecToFloat(undelegation.Amount),
+					UndelegationEpoch:   uint64(undelegation.Epoch),
+					ReleaseEpoch:        releaseEpoch,
+					EstimatedRelease:    estimateEpochStart(releaseEpoch, currentEpoch, epochStart, epochDuration),
+				}
+
+				validatorUndelegations.Amount += pending.Amount
+				validatorUndelegations.Undelegations++
+				scheduleMapping[releaseEpoch] += pending.Amount
+
+				report.Undelegations = append(report.Undelegations, pending)
+			}
+		}
+
+		if validatorUndelegations.Undelegations > 0 {
+			report.TotalAmount += validatorUndelegations.Amount
+			report.Validators = append(report.Validators, validatorUndelegations)
+		}
+	}
+
+	if len(report.Undelegations) == 0 {
+		fmt.Printf("None of the %d matching validators have any pending undelegations\n", len(validatorResults))
+		return nil
+	}
+
+	sort.SliceStable(report.Validators, func(i, j int) bool {
+		return report.Validators[i].Amount > report.Validators[j].Amount
+	})
+
+	sort.SliceStable(report.Undelegations, func(i, j int) bool {
+		return report.Undelegations[i].ReleaseEpoch < report.Undelegations[j].ReleaseEpoch
+	})
+
+	report.Schedule = unlockSchedule(scheduleMapping, currentEpoch, epochStart, epochDuration)
+
+	for _, validatorUndelegations := range report.Validators {
+		fmt.Printf("Validator %s (%s) - undelegating: %.2f ONE across %d undelegation(s)\n", validatorUndelegations.Name, validatorUndelegations.Address, validatorUndelegations.Amount, validatorUndelegations.Undelegations)
+	}
+
+	for _, unlockEpoch := range report.Schedule {
+		if unlockEpoch.Amount > 0 {
+			fmt.Printf("Epoch %d (~%s) - releasing: %.2f ONE, cumulative: %.2f ONE\n", unlockEpoch.Epoch, unlockEpoch.EstimatedRelease.Format(time.RFC3339), unlockEpoch.Amount, unlockEpoch.Cumulative)
+		}
+	}
+
+	fmt.Printf("Total amount undelegating network wide: %.2f ONE across %d undelegation(s) from %d validator(s)\n", report.TotalAmount, len(report.Undelegations), len(report.Validators))
+
+	if err = chartUnlockSchedule(report); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(config.Configuration.Export.Format) {
+	case "csv":
+		csvPath, err := exportUndelegationsToCSV(report.Undelegations)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported pending undelegations to %s\n", csvPath)
+		}
+
+		csvPath, err = exportUnlockScheduleToCSV(report.Schedule)
+		if err != nil {
+			return err
+		} else if csvPath != "" {
+			fmt.Printf("Successfully exported the unlock schedule to %s\n", csvPath)
+		}
+	case "json":
+		jsonPath, err := export.ExportJSON(fmt.Sprintf("validators/undelegations-%s-UTC.json", utils.FormattedTimeString(report.Time)), report)
+		if err != nil {
+			return err
+		} else if jsonPath != "" {
+			fmt.Printf("Successfully exported pending undelegations to %s\n", jsonPath)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// epochTiming - identify the current epoch, when it started and the duration of the previous epoch
+func epochTiming() (currentEpoch uint64, epochStart time.Time, epochDuration time.Duration, err error) {
+	node := config.Configuration.Network.API.NodeAddress(0)
+
+	header, err := rpc.GetLatestHeader(node)
+	if err != nil {
+		return 0, epochStart, 0, err
+	}
+
+	currentEpoch = header.Epoch
+	if currentEpoch < 2 {
+		return 0, epochStart, 0, fmt.Errorf("at least two completed epochs are required to estimate the epoch duration, current epoch: %d", currentEpoch)
+	}
+
+	previousEnd, err := epochEndTime(currentEpoch-1, node)
+	if err != nil {
+		return 0, epochStart, 0, err
+	}
+
+	earlierEnd, err := epochEndTime(currentEpoch-2, node)
+	if err != nil {
+		return 0, epochStart, 0, err
+	}
+
+	return currentEpoch, previousEnd, previousEnd.Sub(earlierEnd), nil
+}
+
+func epochEndTime(epoch uint64, node string) (time.Time, error) {
+	blockNumber, err := rpc.EpochLastBlock(epoch, node)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	block, err := sdkRPC.GetBlockByNumber(blockNumber, false, node)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return block.Timestamp, nil
+}
+
+// releaseEpoch - the epoch at the start of which an undelegation is available again
+// The protocol pays out undelegations in the last block of epoch E once E - undelegationEpoch >= LockPeriodInEpoch or E - lastEpochInCommittee >= LockPeriodInEpoch,
+// the tokens are thus available from the start of E+1 - undelegations that are already releasable are paid out at the end of the current epoch
+func releaseEpoch(undelegationEpoch uint64, lastEpochInCommittee uint64, currentEpoch uint64) uint64 {
+	lockedFrom := undelegationEpoch
+	if lastEpochInCommittee < lockedFrom {
+		lockedFrom = lastEpochInCommittee
+	}
+
+	release := lockedFrom + stakingTypes.LockPeriodInEpoch + 1
+	if release <= currentEpoch {
+		release = currentEpoch + 1
+	}
+
+	return release
+}
+
+func estimateEpochStart(epoch uint64, currentEpoch uint64, currentEpochStart time.Time, epochDuration time.Duration) time.Time {
+	return currentEpochStart.Add(time.Duration(int64(epoch)-int64(currentEpoch)) * epochDuration)
+}
+
+// unlockSchedule - the amount released per epoch, including epochs without any releases
+func unlockSchedule(scheduleMapping map[uint64]float64, currentEpoch uint64, epochStart time.Time, epochDuration time.Duration) []UnlockEpoch {
+	epochs := []uint64{}
+	for epoch := range scheduleMapping {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] < epochs[j]
+	})
+
+	schedule := []UnlockEpoch{}
+	cumulative := 0.0
+	for epoch := epochs[0]; epoch <= epochs[len(epochs)-1]; epoch++ {
+		cumulative += scheduleMapping[epoch]
+		schedule = append(schedule, UnlockEpoch{
+			Epoch:            epoch,
+			EstimatedRelease: estimateEpochStart(epoch, currentEpoch, epochStart, epochDuration),
+			Amount:           scheduleMapping[epoch],
+			Cumulative:       cumulative,
+		})
+	}
+
+	return schedule
+}
+
+func chartUnlockSchedule(report UndelegationReport) error {
+	xAxisData := []time.Time{}
+	amountData := []float64{}
+	cumulativeData := []float64{}
+
+	for _, unlockEpoch := range report.Schedule {
+		xAxisData = append(xAxisData, unlockEpoch.EstimatedRelease)
+		amountData = append(amountData, unlockEpoch.Amount)
+		cumulativeData = append(cumulativeData, unlockEpoch.Cumulative)
+	}
+
+	first, last := report.Schedule[0], report.Schedule[len(report.Schedule)-1]
+	fileName := fmt.Sprintf("validators/%s-unlock-schedule.png", strings.ToLower(config.Configuration.Network.Name))
+
+	return charts.GenerateMultiTimeSeriesChart(
+		fileName,
+		"Estimated Release Date",
+		"Released (ONE)",
+		"Cumulative Released (ONE)",
+		xAxisData,
+		[]charts.Series{
+			{Title: "Released", YValues: amountData},
+			{Title: "Cumulative Released", YValues: cumulativeData, Secondary: true},
+		},
+		[]string{
+			"Open Staking Undelegation Unlock Schedule",
+			fmt.Sprintf("Network: %s", config.Configuration.Network.Name),
+			fmt.Sprintf("Release epochs: %d - %d (current epoch: %d)", first.Epoch, last.Epoch, report.CurrentEpoch),
+			fmt.Sprintf("Total undelegating: %s", formatONE(report.TotalAmount)),
+		},
+	)
+}
+
+func exportUndelegationsToCSV(undelegations []PendingUndelegation) (string, error) {
+	fileName := fmt.Sprintf("validators/undelegations-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	headers := []string{"Validator Name"}
+	headers = append(headers, addressHeaders("Validator Address")...)
+	headers = append(headers, addressHeaders("Delegator Address")...)
+	headers = append(headers, "Amount", "Undelegation Epoch", "Release Epoch", "Estimated Release")
+
+	rows := [][]string{headers}
+
+	for _, undelegation := range undelegations {
+		row := []string{undelegation.ValidatorName}
+		row = append(row, addressColumns(undelegation.ValidatorAddress, undelegation.ValidatorHexAddress)...)
+		row = append(row, addressColumns(undelegation.DelegatorAddress, undelegation.DelegatorHexAddress)...)
+		row = append(row,
+			fmt.Sprintf("%f", undelegation.Amount),
+			fmt.Sprintf("%d", undelegation.UndelegationEpoch),
+			fmt.Sprintf("%d", undelegation.ReleaseEpoch),
+			undelegation.EstimatedRelease.Format(time.RFC3339),
+		)
+		rows = append(rows, row)
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
+
+func exportUnlockScheduleToCSV(schedule []UnlockEpoch) (string, error) {
+	fileName := fmt.Sprintf("validators/unlock-schedule-%s-UTC.csv", utils.FormattedTimeString(time.Now().UTC()))
+
+	rows := [][]string{
+		{
+			"Epoch",
+			"Estimated Release",
+			"Amount",
+			"Cumulative",
+		},
+	}
+
+	for _, unlockEpoch := range schedule {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", unlockEpoch.Epoch),
+			unlockEpoch.EstimatedRelease.Format(time.RFC3339),
+			fmt.Sprintf("%f", unlockEpoch.Amount),
+			fmt.Sprintf("%f", unlockEpoch.Cumulative),
+		})
+	}
+
+	csvPath, err := export.ExportCSV(fileName, rows)
+	if err != nil {
+		return "", err
+	}
+
+	return csvPath, nil
+}
@@ -0,0 +1,29 @@
+package validators
+
+import "testing"
+
+func TestReleaseEpoch(t *testing.T) {
+	tests := []struct {
+		undelegationEpoch    uint64
+		lastEpochInCommittee uint64
+		currentEpoch         uint64
+		expected             uint64
+	}{
+		// Elected validators: the lock period starts at the undelegation
+		{100, 102, 102, 108},
+		{102, 102, 102, 110},
+		// The validator left the committee before the undelegation, so the tokens are released earlier
+		{102, 98, 102, 106},
+		// Releasable at the end of the current epoch
+		{95, 102, 102, 103},
+		// The validator has been out of the committee for the full lock period, the tokens are released at the end of the current epoch
+		{102, 90, 102, 103},
+		{102, 0, 102, 103},
+	}
+
+	for _, test := range tests {
+		if actual := releaseEpoch(test.undelegationEpoch, test.lastEpochInCommittee, test.currentEpoch); actual != test.expected {
+			t.Errorf("releaseEpoch(%d, %d, %d) = %d, expected %d", test.undelegationEpoch, test.lastEpochInCommittee, test.currentEpoch, actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SebastianJ/harmony-stats/charts"
+	"github.com/SebastianJ/harmony-stats/config"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+	"github.com/wcharczuk/go-chart"
+)
+
+// Uptime - signing performance for a given validator
+type Uptime struct {
+	CurrentEpochSigned uint64  `json:"current-epoch-signed"`
+	CurrentEpochToSign uint64  `json:"current-epoch-to-sign"`
+	CurrentEpochUptime float64 `json:"current-epoch-uptime"`
+	LifetimeSigned     uint64  `json:"lifetime-signed"`
+	LifetimeToSign     uint64  `json:"lifetime-to-sign"`
+	LifetimeUptime     float64 `json:"lifetime-uptime"`
+	BelowThreshold     bool    `json:"below-threshold"`
+}
+
+// CalculateUptime - calculate the current epoch and lifetime uptime percentages for a given validator
+// Validators that haven't had any blocks to sign during the current epoch are never flagged as being below the threshold
+func CalculateUptime(validatorResult sdkValidator.RPCValidatorResult) Uptime {
+	uptime := Uptime{
+		CurrentEpochSigned: uint64(validatorResult.CurrentEpochPerformance.CurrentEpochSigned),
+		CurrentEpochToSign: uint64(validatorResult.CurrentEpochPerformance.CurrentEpochToSign),
+		LifetimeSigned:     uint64(validatorResult.Lifetime.Blocks.Signed),
+		LifetimeToSign:     uint64(validatorResult.Lifetime.Blocks.ToSign),
+	}
+
+	uptime.CurrentEpochUptime = percentage(uptime.CurrentEpochSigned, uptime.CurrentEpochToSign)
+	uptime.LifetimeUptime = percentage(uptime.LifetimeSigned, uptime.LifetimeToSign)
+
+	uptime.BelowThreshold = uptime.CurrentEpochToSign > 0 && belowUptimeThreshold(uptime.CurrentEpochSigned, uptime.CurrentEpochToSign)
+
+	return uptime
+}
+
+// belowUptimeThreshold - signing at or below the threshold is considered insufficient
+// Without a custom threshold the protocol's availability check is used - the protocol computes signed/toSign as a decimal and checks whether it's <= 2/3,
+// signed*3 <= toSign*2 is the equivalent integer comparison which avoids float rounding
+func belowUptimeThreshold(signed uint64, toSign uint64) bool {
+	if config.ValidatorArgs.Uptime.Threshold > 0 {
+		return percentage(signed, toSign) <= config.ValidatorArgs.Uptime.Threshold
+	}
+
+	return signed*3 <= toSign*2
+}
+
+// uptimeThreshold - the uptime threshold in percent, defaults to the protocol's threshold of 2/3
+func uptimeThreshold() float64 {
+	if config.ValidatorArgs.Uptime.Threshold > 0 {
+		return config.ValidatorArgs.Uptime.Threshold
+	}
+
+	return 200.0 / 3.0
+}
+
+func percentage(signed uint64, toSign uint64) float64 {
+	if toSign == 0 {
+		return 0.0
+	}
+
+	return float64(signed) / float64(toSign) * 100.0
+}
+
+// UptimeGraph - generate a graph of the current epoch uptime of validators, starting with the worst performing validators
+func UptimeGraph() error {
+	fmt.Printf("Will generate a graph of validator uptime - network: %s, mode: %s, node: %s\n", config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	validatorResults, err := Filtered()
+	if err != nil {
+		return err
+	}
+
+	type validatorUptime struct {
+		name    string
+		address string
+		uptime  Uptime
+	}
+
+	uptimes := []validatorUptime{}
+	for _, validatorResult := range validatorResults {
+		uptime := CalculateUptime(validatorResult)
+		if uptime.CurrentEpochToSign > 0 {
+			uptimes = append(uptimes, validatorUptime{validatorResult.Validator.Name, formatAddress(validatorResult.Validator.Address), uptime})
+		}
+	}
+
+	if len(uptimes) == 0 {
+		return fmt.Errorf("none of the matching validators have had any blocks to sign during the current epoch")
+	}
+
+	sort.SliceStable(uptimes, func(i, j int) bool {
+		return uptimes[i].uptime.CurrentEpochUptime < uptimes[j].uptime.CurrentEpochUptime
+	})
+
+	fmt.Printf("Found a total of %d validators with blocks to sign during the current epoch\n", len(uptimes))
+
+	limit := config.ValidatorArgs.Uptime.Limit
+	if limit <= 0 || limit > len(uptimes) {
+		limit = len(uptimes)
+	}
+
+	bars := []chart.Value{}
+	for _, uptime := range uptimes[:limit] {
+		bar := chart.Value{
+			Label: validatorLabel(uptime.name, uptime.address),
+			Value: uptime.uptime.CurrentEpochUptime,
+		}
+
+		if uptime.uptime.BelowThreshold {
+			bar.Style = charts.AlertBarStyle()
+		}
+
+		bars = append(bars, bar)
+	}
+
+	fileName := fmt.Sprintf("validators/%s-uptime.png", strings.ToLower(config.Configuration.Network.Name))
+	title := fmt.Sprintf("Open Staking Validator Uptime - Current Epoch (threshold: %.2f%%)", uptimeThreshold())
+
+	if err = charts.GenerateBarChart(fileName, title, "Uptime", func(v interface{}) string {
+		return fmt.Sprintf("%.0f%%", v.(float64))
+	}, bars); err != nil {
+		return err
+	}
+
+	return nil
+}
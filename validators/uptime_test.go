@@ -0,0 +1,44 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+)
+
+func TestCalculateUptimeThreshold(t *testing.T) {
+	defer func(threshold float64) { config.ValidatorArgs.Uptime.Threshold = threshold }(config.ValidatorArgs.Uptime.Threshold)
+
+	tests := []struct {
+		threshold float64
+		signed    uint32
+		toSign    uint32
+		expected  bool
+	}{
+		// Equivalent to the protocol's availability check signed/toSign <= 2/3
+		{0, 133, 200, true},
+		{0, 2, 3, true},
+		{0, 200, 300, true},
+		{0, 201, 300, false},
+		{0, 134, 200, false},
+		{0, 0, 0, false},
+		{0, 0, 1, true},
+		// Custom thresholds are compared to the uptime percentage
+		{90, 180, 200, true},
+		{90, 181, 200, false},
+		{50, 133, 200, false},
+	}
+
+	for _, test := range tests {
+		config.ValidatorArgs.Uptime.Threshold = test.threshold
+
+		validatorResult := sdkValidator.RPCValidatorResult{}
+		validatorResult.CurrentEpochPerformance.CurrentEpochSigned = test.signed
+		validatorResult.CurrentEpochPerformance.CurrentEpochToSign = test.toSign
+
+		if actual := CalculateUptime(validatorResult).BelowThreshold; actual != test.expected {
+			t.Errorf("CalculateUptime(%d/%d) with threshold %.2f flagged as below threshold: %t, expected %t", test.signed, test.toSign, test.threshold, actual, test.expected)
+		}
+	}
+}
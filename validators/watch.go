@@ -0,0 +1,321 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SebastianJ/harmony-stats/config"
+	"github.com/SebastianJ/harmony-stats/logger"
+	"github.com/SebastianJ/harmony-stats/utils"
+	sdkValidator "github.com/harmony-one/go-lib/staking/validator"
+)
+
+// Alert - an alert raised while watching a validator
+type Alert struct {
+	Time       time.Time `json:"time"`
+	Type       string    `json:"type"`
+	Name       string    `json:"name"`
+	Address    string    `json:"address"`
+	HexAddress string    `json:"hex-address,omitempty"`
+	Message    string    `json:"message"`
+	Previous   string    `json:"previous,omitempty"`
+	Current    string    `json:"current"`
+}
+
+// watchState - the state of a watched validator as of the previous poll
+type watchState struct {
+	Elected        bool
+	CommissionRate float64
+	UptimeBelow    bool
+	BalanceBelow   bool
+}
+
+// watchSample - the values of a watched validator retrieved during a single poll
+type watchSample struct {
+	Name           string
+	Address        string
+	HexAddress     string
+	Elected        bool
+	CommissionRate float64
+	Uptime         Uptime
+	Balance        *float64
+}
+
+// Watch - periodically poll the watched validators and raise alerts when their state changes for the worse
+// Alerts are only raised on transitions, e.g. an uptime alert is raised once when the uptime drops below the threshold and not on every poll after that
+func Watch() error {
+	if len(config.ValidatorArgs.Watch.Addresses) == 0 {
+		return fmt.Errorf("you need to specify the addresses of the validators to watch using --addresses")
+	}
+
+	if config.ValidatorArgs.Watch.Interval <= 0 {
+		return fmt.Errorf("the polling interval has to be at least 1 second")
+	}
+
+	addresses := []string{}
+	for _, address := range config.ValidatorArgs.Watch.Addresses {
+		bech32Address, err := utils.ToBech32Address(strings.TrimSpace(address))
+		if err != nil {
+			return err
+		}
+		addresses = append(addresses, bech32Address)
+	}
+
+	for _, output := range config.ValidatorArgs.Watch.Outputs {
+		switch strings.ToLower(output) {
+		case "stdout", "jsonl":
+		case "webhook":
+			if config.ValidatorArgs.Watch.WebhookURL == "" {
+				return fmt.Errorf("you need to specify the webhook url using --webhook.url when using the webhook output")
+			}
+		default:
+			return fmt.Errorf("invalid alert output %s - valid options: stdout, jsonl or webhook", output)
+		}
+	}
+
+	fmt.Printf("Will watch %d validator(s) using an interval of %d second(s) - network: %s, mode: %s, node: %s\n", len(addresses), config.ValidatorArgs.Watch.Interval, config.Configuration.Network.Name, config.Configuration.Network.Mode, config.Configuration.Network.Node)
+
+	interval := time.Duration(config.ValidatorArgs.Watch.Interval) * time.Second
+	var deadline time.Time
+	if config.ValidatorArgs.Watch.Duration > 0 {
+		deadline = time.Now().Add(time.Duration(config.ValidatorArgs.Watch.Duration) * time.Second)
+	}
+
+	states := make(map[string]*watchState)
+
+	var webhooks *webhookQueue
+	for _, output := range config.ValidatorArgs.Watch.Outputs {
+		if strings.ToLower(output) == "webhook" {
+			webhooks = newWebhookQueue()
+			defer webhooks.close()
+			break
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, address := range addresses {
+			sample, err := takeWatchSample(address)
+			if err != nil {
+				logger.ErrorLog(fmt.Sprintf("Failed to look up validator %s - error: %s", formatAddress(address), err.Error()))
+				continue
+			}
+
+			for _, alert := range evaluateWatchSample(states[address], sample) {
+				emitAlert(alert, webhooks)
+			}
+
+			states[address] = &watchState{
+				Elected:        sample.Elected,
+				CommissionRate: sample.CommissionRate,
+				UptimeBelow:    uptimeBelowThreshold(states[address], sample),
+				BalanceBelow:   balanceBelowThreshold(states[address], sample),
+			}
+		}
+
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			break
+		}
+
+		<-ticker.C
+	}
+
+	return nil
+}
+
+func takeWatchSample(address string) (watchSample, error) {
+	validatorResult, err := sdkValidator.Information(config.Configuration.Network.API.NodeAddress(0), address)
+	if err != nil {
+		return watchSample{}, err
+	}
+
+	if validatorResult.Validator.Address == "" {
+		return watchSample{}, fmt.Errorf("couldn't find a validator with the address %s", formatAddress(address))
+	}
+
+	sample := watchSample{
+		Name:           validatorResult.Validator.Name,
+		Address:        formatAddress(address),
+		HexAddress:     hexAddress(address),
+		Elected:        validatorResult.CurrentlyInCommittee,
+		CommissionRate: utils.DecToFloat(validatorResult.Validator.Rate),
+		Uptime:         CalculateUptime(validatorResult),
+	}
+
+	// Balances are only looked up when there's a threshold to compare them against
+	if config.ValidatorArgs.Watch.BalanceThreshold > 0 {
+		totalBalance, err := config.Configuration.Network.API.GetTotalBalance(address)
+		if err != nil {
+			logger.ErrorLog(fmt.Sprintf("Failed to look up the balance for validator wallet %s - error: %s", sample.Address, err.Error()))
+		} else {
+			balance := utils.DecToFloat(totalBalance)
+			sample.Balance = &balance
+		}
+	}
+
+	return sample, nil
+}
+
+// evaluateWatchSample - compare a sample to the validator's previous state, previous is nil for the first poll
+func evaluateWatchSample(previous *watchState, sample watchSample) []Alert {
+	alerts := []Alert{}
+	now := time.Now().UTC()
+
+	newAlert := func(alertType string, message string, previousValue string, currentValue string) Alert {
+		return Alert{
+			Time:       now,
+			Type:       alertType,
+			Name:       sample.Name,
+			Address:    sample.Address,
+			HexAddress: sample.HexAddress,
+			Message:    message,
+			Previous:   previousValue,
+			Current:    currentValue,
+		}
+	}
+
+	if previous != nil && previous.Elected && !sample.Elected {
+		alerts = append(alerts, newAlert("committee", fmt.Sprintf("Validator %s (%s) left the committee", sample.Name, sample.Address), "elected", "not elected"))
+	}
+
+	if uptimeBelowThreshold(previous, sample) && (previous == nil || !previous.UptimeBelow) {
+		alerts = append(alerts, newAlert("uptime", fmt.Sprintf("Validator %s (%s) current epoch uptime dropped below %.2f%%: %.2f%% (%d/%d)", sample.Name, sample.Address, uptimeThreshold(), sample.Uptime.CurrentEpochUptime, sample.Uptime.CurrentEpochSigned, sample.Uptime.CurrentEpochToSign), "", fmt.Sprintf("%.2f", sample.Uptime.CurrentEpochUptime)))
+	}
+
+	if sample.Balance != nil && balanceBelowThreshold(previous, sample) && (previous == nil || !previous.BalanceBelow) {
+		alerts = append(alerts, newAlert("balance", fmt.Sprintf("Validator %s (%s) wallet balance dropped below %.2f ONE: %.2f ONE", sample.Name, sample.Address, config.ValidatorArgs.Watch.BalanceThreshold, *sample.Balance), "", fmt.Sprintf("%f", *sample.Balance)))
+	}
+
+	if previous != nil && previous.CommissionRate != sample.CommissionRate {
+		alerts = append(alerts, newAlert("commission", fmt.Sprintf("Validator %s (%s) changed its commission rate from %.2f%% to %.2f%%", sample.Name, sample.Address, previous.CommissionRate*100, sample.CommissionRate*100), fmt.Sprintf("%f", previous.CommissionRate), fmt.Sprintf("%f", sample.CommissionRate)))
+	}
+
+	return alerts
+}
+
+// uptimeBelowThreshold - the current epoch uptime is only meaningful once enough blocks have been signed, so right after an epoch
+// rollover (when the current epoch counters have just been reset) the previous state is kept instead
+func uptimeBelowThreshold(previous *watchState, sample watchSample) bool {
+	if sample.Uptime.CurrentEpochToSign < config.ValidatorArgs.Watch.MinimumToSign {
+		return previous != nil && previous.UptimeBelow
+	}
+
+	return sample.Uptime.BelowThreshold
+}
+
+// balanceBelowThreshold - a failed balance lookup keeps the previous state, otherwise the alert would be raised again once the lookup succeeds
+func balanceBelowThreshold(previous *watchState, sample watchSample) bool {
+	if sample.Balance == nil {
+		return previous != nil && previous.BalanceBelow
+	}
+
+	return config.ValidatorArgs.Watch.BalanceThreshold > 0 && *sample.Balance < config.ValidatorArgs.Watch.BalanceThreshold
+}
+
+// emitAlert - send an alert to all configured outputs, failing outputs are logged but don't stop the watch
+// Webhook alerts are queued and posted in the background so a slow webhook doesn't delay the polling
+func emitAlert(alert Alert, webhooks *webhookQueue) {
+	for _, output := range config.ValidatorArgs.Watch.Outputs {
+		switch strings.ToLower(output) {
+		case "stdout":
+			logger.WarningLog(alert.Message)
+		case "jsonl":
+			if err := appendAlert(alert); err != nil {
+				logger.ErrorLog(fmt.Sprintf("Failed to write alert to the alert log - error: %s", err.Error()))
+			}
+		case "webhook":
+			webhooks.enqueue(alert)
+		}
+	}
+}
+
+// appendAlert - append an alert as a single json line to the network's alert log
+func appendAlert(alert Alert) error {
+	filePath := filepath.Join(config.Configuration.Export.Path, "validators", fmt.Sprintf("%s-alerts.jsonl", strings.ToLower(config.Configuration.Network.Name)))
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+
+	return err
+}
+
+// webhookQueue - posts alerts to the webhook one at a time from a separate goroutine, preserving their order
+type webhookQueue struct {
+	alerts chan Alert
+	wg     sync.WaitGroup
+}
+
+func newWebhookQueue() *webhookQueue {
+	queue := &webhookQueue{alerts: make(chan Alert, 100)}
+	queue.wg.Add(1)
+	go queue.run()
+
+	return queue
+}
+
+func (queue *webhookQueue) run() {
+	defer queue.wg.Done()
+
+	for alert := range queue.alerts {
+		if err := postAlert(alert); err != nil {
+			logger.ErrorLog(fmt.Sprintf("Failed to post alert to %s - error: %s", config.ValidatorArgs.Watch.WebhookURL, err.Error()))
+		}
+	}
+}
+
+// enqueue - alerts are dropped when the queue is full rather than blocking the polling
+func (queue *webhookQueue) enqueue(alert Alert) {
+	select {
+	case queue.alerts <- alert:
+	default:
+		logger.ErrorLog(fmt.Sprintf("Dropped alert for %s since the webhook queue is full - message: %s", alert.Address, alert.Message))
+	}
+}
+
+// close - wait for the queued alerts to be posted
+func (queue *webhookQueue) close() {
+	close(queue.alerts)
+	queue.wg.Wait()
+}
+
+func postAlert(alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	client := http.Client{Timeout: time.Duration(config.Configuration.Network.Timeout) * time.Second}
+	response, err := client.Post(config.ValidatorArgs.Watch.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", response.Status)
+	}
+
+	return nil
+}
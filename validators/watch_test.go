@@ -0,0 +1,122 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/SebastianJ/harmony-stats/config"
+)
+
+func testWatchSample(elected bool, commissionRate float64, signed uint64, toSign uint64, balance *float64) watchSample {
+	uptime := Uptime{CurrentEpochSigned: signed, CurrentEpochToSign: toSign}
+	uptime.BelowThreshold = toSign > 0 && belowUptimeThreshold(signed, toSign)
+
+	return watchSample{
+		Name:           "Validator",
+		Address:        "one1validator",
+		Elected:        elected,
+		CommissionRate: commissionRate,
+		Uptime:         uptime,
+		Balance:        balance,
+	}
+}
+
+func alertTypes(alerts []Alert) []string {
+	types := []string{}
+	for _, alert := range alerts {
+		types = append(types, alert.Type)
+	}
+
+	return types
+}
+
+func TestEvaluateWatchSample(t *testing.T) {
+	defer func(watch config.WatchFlags, uptime config.UptimeFlags) {
+		config.ValidatorArgs.Watch = watch
+		config.ValidatorArgs.Uptime = uptime
+	}(config.ValidatorArgs.Watch, config.ValidatorArgs.Uptime)
+
+	config.ValidatorArgs.Watch = config.WatchFlags{MinimumToSign: 30, BalanceThreshold: 100}
+	config.ValidatorArgs.Uptime = config.UptimeFlags{}
+
+	low, high := 50.0, 500.0
+
+	tests := []struct {
+		name     string
+		previous *watchState
+		sample   watchSample
+		expected []string
+	}{
+		{"first poll without issues", nil, testWatchSample(true, 0.1, 100, 100, &high), []string{}},
+		{"first poll below the thresholds", nil, testWatchSample(true, 0.1, 10, 100, &low), []string{"uptime", "balance"}},
+		{"left the committee", &watchState{Elected: true, CommissionRate: 0.1}, testWatchSample(false, 0.1, 100, 100, nil), []string{"committee"}},
+		{"commission change", &watchState{Elected: true, CommissionRate: 0.1}, testWatchSample(true, 0.2, 100, 100, nil), []string{"commission"}},
+		{"uptime drop", &watchState{Elected: true, CommissionRate: 0.1}, testWatchSample(true, 0.1, 60, 100, nil), []string{"uptime"}},
+		// Signing exactly 2/3 of the blocks fails the protocol's availability check
+		{"uptime at the threshold", &watchState{Elected: true, CommissionRate: 0.1}, testWatchSample(true, 0.1, 60, 90, nil), []string{"uptime"}},
+		{"uptime still below", &watchState{Elected: true, CommissionRate: 0.1, UptimeBelow: true}, testWatchSample(true, 0.1, 60, 100, nil), []string{}},
+		{"balance still below", &watchState{Elected: true, CommissionRate: 0.1, BalanceBelow: true}, testWatchSample(true, 0.1, 100, 100, &low), []string{}},
+		// A failed balance lookup in between shouldn't raise the balance alert again
+		{"balance still below after a failed lookup", &watchState{Elected: true, CommissionRate: 0.1, BalanceBelow: true}, testWatchSample(true, 0.1, 100, 100, nil), []string{}},
+		// The current epoch counters are reset after an epoch rollover, a single missed block shouldn't raise an alert
+		{"epoch rollover", &watchState{Elected: true, CommissionRate: 0.1}, testWatchSample(true, 0.1, 0, 1, nil), []string{}},
+		{"epoch rollover on the first poll", nil, testWatchSample(true, 0.1, 1, 2, &high), []string{}},
+		{"enough blocks to sign after the rollover", &watchState{Elected: true, CommissionRate: 0.1}, testWatchSample(true, 0.1, 10, 30, nil), []string{"uptime"}},
+	}
+
+	for _, test := range tests {
+		actual := alertTypes(evaluateWatchSample(test.previous, test.sample))
+		if len(actual) != len(test.expected) {
+			t.Errorf("%s: evaluateWatchSample raised %v, expected %v", test.name, actual, test.expected)
+			continue
+		}
+
+		for index := range actual {
+			if actual[index] != test.expected[index] {
+				t.Errorf("%s: evaluateWatchSample raised %v, expected %v", test.name, actual, test.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestBalanceBelowThresholdKeepsStateWithoutBalance(t *testing.T) {
+	defer func(watch config.WatchFlags) { config.ValidatorArgs.Watch = watch }(config.ValidatorArgs.Watch)
+	config.ValidatorArgs.Watch = config.WatchFlags{BalanceThreshold: 100}
+
+	low, high := 50.0, 500.0
+
+	if !balanceBelowThreshold(&watchState{BalanceBelow: true}, testWatchSample(true, 0.1, 100, 100, nil)) {
+		t.Errorf("balanceBelowThreshold didn't keep the previous state when the balance lookup failed")
+	}
+
+	if balanceBelowThreshold(nil, testWatchSample(true, 0.1, 100, 100, nil)) {
+		t.Errorf("balanceBelowThreshold flagged a validator without a balance on the first poll")
+	}
+
+	if !balanceBelowThreshold(&watchState{}, testWatchSample(true, 0.1, 100, 100, &low)) {
+		t.Errorf("balanceBelowThreshold didn't flag a balance below the threshold")
+	}
+
+	if balanceBelowThreshold(&watchState{BalanceBelow: true}, testWatchSample(true, 0.1, 100, 100, &high)) {
+		t.Errorf("balanceBelowThreshold didn't clear the state once the balance recovered")
+	}
+}
+
+func TestUptimeBelowThresholdKeepsStateAfterRollover(t *testing.T) {
+	defer func(watch config.WatchFlags) { config.ValidatorArgs.Watch = watch }(config.ValidatorArgs.Watch)
+	config.ValidatorArgs.Watch = config.WatchFlags{MinimumToSign: 30}
+
+	sample := testWatchSample(true, 0.1, 5, 5, nil)
+
+	if !uptimeBelowThreshold(&watchState{UptimeBelow: true}, sample) {
+		t.Errorf("uptimeBelowThreshold didn't keep the previous state with too few blocks to sign")
+	}
+
+	if uptimeBelowThreshold(&watchState{UptimeBelow: false}, testWatchSample(true, 0.1, 0, 5, nil)) {
+		t.Errorf("uptimeBelowThreshold flagged a validator with too few blocks to sign")
+	}
+
+	if uptimeBelowThreshold(&watchState{UptimeBelow: true}, testWatchSample(true, 0.1, 30, 30, nil)) {
+		t.Errorf("uptimeBelowThreshold didn't clear the state once enough blocks were signed")
+	}
+}